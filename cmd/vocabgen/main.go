@@ -0,0 +1,257 @@
+// vocabgen generates a Go package having the terms of a vocabulary as
+// constants from the RDF/XML ontology of the vocabulary.
+//
+// Usage:
+//
+//	vocabgen -in spdx.rdf -namespace http://spdx.org/rdf/terms# -package spdx -out spdx.go
+//
+// Every IRI of the namespace which is a subject in the ontology is a term of
+// the vocabulary. The rdfs:comment of a term is used as the doc comment of
+// its constant. It is meant to be run by go generate.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/spdx/gordf/namespace"
+	"github.com/spdx/gordf/namespace/rdf"
+	"github.com/spdx/gordf/namespace/rdfs"
+	"github.com/spdx/gordf/rdfloader"
+	"github.com/spdx/gordf/rdfloader/parser"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	rdfType     = string(rdf.Type)
+	rdfsComment = string(rdfs.Comment)
+)
+
+// term of a vocabulary.
+type term struct {
+	// name of the term in the namespace. For example, licenseId is the name
+	// of the term http://spdx.org/rdf/terms#licenseId
+	name string
+	// Class or Property if the term is typed as a class or a property by the
+	// ontology. It is empty otherwise.
+	kind string
+	// first sentence of the rdfs:comment of the term.
+	comment string
+	// identifier of the constant of the term.
+	ident string
+}
+
+func main() {
+	inputPath := flag.String("in", "", "path of the RDF/XML ontology file")
+	outputPath := flag.String("out", "", "path of the generated go file. Written to stdout if empty")
+	packageName := flag.String("package", "", "name of the generated package")
+	ns := flag.String("namespace", "", "namespace of the terms of the vocabulary")
+	flag.Parse()
+
+	if *inputPath == "" || *packageName == "" || *ns == "" {
+		fmt.Fprintf(os.Stderr, "Usage: %v -in <ontology.rdf> -namespace <namespace> -package <name> [-out <file.go>]\n", os.Args[0])
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	rdfParser, err := rdfloader.LoadFromFilePath(*inputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading the ontology: %v\n", err)
+		os.Exit(1)
+	}
+	terms, nsStart, err := collectTerms(rdfParser.Triples, *ns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error collecting the terms: %v\n", err)
+		os.Exit(1)
+	}
+	src, err := generate(*packageName, filepath.Base(*inputPath), nsStart, terms)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error generating the package: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *outputPath == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err = ioutil.WriteFile(*outputPath, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing the generated package: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// returns the terms of the namespace sorted by their names and the start
+// of the IRIs of the terms. A term is an IRI subject of the triples which
+// is a name in the namespace.
+func collectTerms(triples []*parser.Triple, ns string) (terms []*term, nsStart string, err error) {
+	nsURI, err := namespace.New(ns)
+	if err != nil {
+		return nil, "", err
+	}
+	start, err := nsURI.Get("")
+	if err != nil {
+		return nil, "", err
+	}
+	nsStart = start.String()
+
+	// a manager having the namespace as the default namespace compacts the
+	// IRIs of the terms to their names.
+	manager := namespace.NewManager()
+	manager.Bind("", start)
+
+	termOf := map[string]*term{}
+	for _, triple := range triples {
+		if triple.Subject.NodeType != parser.IRI {
+			continue
+		}
+		name, err := manager.Compact(triple.Subject.ID)
+		if err != nil {
+			// subject is not a name in the namespace.
+			continue
+		}
+		t, exists := termOf[name]
+		if !exists {
+			t = &term{name: name}
+			termOf[name] = t
+			terms = append(terms, t)
+		}
+		switch triple.Predicate.ID {
+		case rdfType:
+			if kind := kindOf(triple.Object.ID); kind != "" {
+				t.kind = kind
+			}
+		case rdfsComment:
+			if triple.Object.Lang == "" || triple.Object.Lang == "en" {
+				t.comment = firstSentence(triple.Object.ID)
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return nil, "", fmt.Errorf("ontology doesn't have any term in the namespace %v", nsStart)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		return terms[i].name < terms[j].name
+	})
+	assignIdentifiers(terms)
+	return terms, nsStart, nil
+}
+
+// returns the kind of the terms typed as the type. Types like rdfs:Class,
+// owl:Class and rdfs:Datatype are classes and types like rdf:Property and
+// owl:ObjectProperty are properties.
+func kindOf(typeIRI string) string {
+	switch {
+	case strings.HasSuffix(typeIRI, "Property"):
+		return "Property"
+	case strings.HasSuffix(typeIRI, "Class"), strings.HasSuffix(typeIRI, "Datatype"):
+		return "Class"
+	}
+	return ""
+}
+
+// returns the first sentence of the text with the whitespaces collapsed.
+// A sentence ends in a period followed by a capitalized word. So,
+// abbreviations like "e.g. a" don't end the sentence.
+func firstSentence(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	for i := 0; i+2 < len(text); i++ {
+		if text[i] == '.' && text[i+1] == ' ' && unicode.IsUpper([]rune(text[i+2:])[0]) {
+			return text[:i+1]
+		}
+	}
+	return text
+}
+
+// returns the exported go identifier of the name. Characters not allowed in
+// an identifier are replaced by an underscore.
+func identifier(name string) string {
+	runes := []rune(name)
+	for i, r := range runes {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			runes[i] = '_'
+		}
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	if !unicode.IsUpper(runes[0]) {
+		// identifiers starting with a digit, an underscore or a letter
+		// without case aren't exported.
+		return "X" + string(runes)
+	}
+	return string(runes)
+}
+
+// assigns an unique identifier to each of the sorted terms. If the names of
+// two terms differ only in the case of their first letter, like the class
+// Checksum and the property checksum, the capitalized name keeps the
+// identifier and the kind of the other term is appended to its identifier.
+func assignIdentifiers(terms []*term) {
+	// NS is the identifier of the namespace.
+	used := map[string]bool{"NS": true}
+	for _, t := range terms {
+		ident := identifier(t.name)
+		if used[ident] {
+			suffix := t.kind
+			if suffix == "" {
+				suffix = "Term"
+			}
+			ident += suffix
+			for i := 2; used[ident]; i++ {
+				ident = fmt.Sprintf("%v%v%d", identifier(t.name), suffix, i)
+			}
+		}
+		used[ident] = true
+		t.ident = ident
+	}
+}
+
+// returns the gofmt-ed source of the package having the terms.
+func generate(packageName, source, nsStart string, terms []*term) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by vocabgen from %v. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %v\n\n", packageName)
+	fmt.Fprintf(&buf, "import \"github.com/spdx/gordf/namespace\"\n\n")
+	fmt.Fprintf(&buf, "// NS is the namespace of the vocabulary.\n")
+	fmt.Fprintf(&buf, "const NS = %v\n\n", strconv.Quote(nsStart))
+	fmt.Fprintf(&buf, "const (\n")
+	for i, t := range terms {
+		if t.comment != "" {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			for _, line := range wrap(t.comment, 76) {
+				fmt.Fprintf(&buf, "// %v\n", line)
+			}
+		}
+		fmt.Fprintf(&buf, "%v namespace.Term = NS + %v\n", t.ident, strconv.Quote(t.name))
+	}
+	fmt.Fprintf(&buf, ")\n")
+	return format.Source(buf.Bytes())
+}
+
+// splits the text into lines of at most width characters. A word longer
+// than the width is kept in a line of its own.
+func wrap(text string, width int) (lines []string) {
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"github.com/spdx/gordf/rdfloader"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_identifier(t *testing.T) {
+	names := map[string]string{
+		"licenseId":              "LicenseId",
+		"Checksum":               "Checksum",
+		"checksumAlgorithm_sha1": "ChecksumAlgorithm_sha1",
+		"http-method":            "Http_method",
+		"_1":                     "X_1",
+		"3d":                     "X3d",
+		"été":                    "Été",
+	}
+	for name, expected := range names {
+		if ident := identifier(name); ident != expected {
+			t.Errorf("expected %v, found %v", expected, ident)
+		}
+	}
+}
+
+func Test_firstSentence(t *testing.T) {
+	// TestCase 1: text is cut after the first sentence.
+	text := "The class of RDF Lists.\n\tIt is a class."
+	if sentence := firstSentence(text); sentence != "The class of RDF Lists." {
+		t.Errorf("expected the first sentence, found %v", sentence)
+	}
+
+	// TestCase 2: abbreviations don't end a sentence.
+	text = "The class of plain (i.e. untyped) literal values."
+	if sentence := firstSentence(text); sentence != text {
+		t.Errorf("expected %v, found %v", text, sentence)
+	}
+}
+
+func Test_assignIdentifiers(t *testing.T) {
+	terms := []*term{
+		{name: "Checksum", kind: "Class"},
+		{name: "NS"},
+		{name: "checksum", kind: "Property"},
+		{name: "nS"},
+	}
+	assignIdentifiers(terms)
+	expected := []string{"Checksum", "NSTerm", "ChecksumProperty", "NSTerm2"}
+	for i := range terms {
+		if terms[i].ident != expected[i] {
+			t.Errorf("expected %v for %v, found %v", expected[i], terms[i].name, terms[i].ident)
+		}
+	}
+}
+
+func Test_generate(t *testing.T) {
+	ontology := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+			xmlns:owl="http://www.w3.org/2002/07/owl#">
+			<owl:Class rdf:about="http://example.com/terms#Checksum">
+				<rdfs:comment xml:lang="fr">Une somme de contrôle.</rdfs:comment>
+				<rdfs:comment>A checksum of a file. It has a value.</rdfs:comment>
+			</owl:Class>
+			<owl:ObjectProperty rdf:about="http://example.com/terms#checksum"/>
+			<owl:Class rdf:about="http://example.com/other#Name"/>
+		</rdf:RDF>`
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(ontology))
+	if err != nil {
+		t.Fatalf("unexpected error loading the ontology: %v", err)
+	}
+
+	// TestCase 1: namespace without any term must raise an error
+	if _, _, err = collectTerms(rdfParser.Triples, "http://example.com/none#"); err == nil {
+		t.Errorf("expected an error stating the namespace doesn't have any term")
+	}
+
+	// TestCase 2: terms of a namespace without a trailing #
+	terms, nsStart, err := collectTerms(rdfParser.Triples, "http://example.com/terms")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src, err := generate("terms", "terms.rdf", nsStart, terms)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `// Code generated by vocabgen from terms.rdf. DO NOT EDIT.
+
+package terms
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://example.com/terms#"
+
+const (
+	// A checksum of a file.
+	Checksum         namespace.Term = NS + "Checksum"
+	ChecksumProperty namespace.Term = NS + "checksum"
+)
+`
+	if string(src) != expected {
+		t.Errorf("expected:\n%v\nfound:\n%v", expected, string(src))
+	}
+}
+
+// generated vocabulary packages must be same as the ones generated from
+// their ontologies.
+func TestVocabularyPackages(t *testing.T) {
+	packages := map[string]string{
+		"rdf":  "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+		"owl":  "http://www.w3.org/2002/07/owl#",
+		"xsd":  "http://www.w3.org/2001/XMLSchema#",
+		"spdx": "http://spdx.org/rdf/terms#",
+	}
+	for name, ns := range packages {
+		dir := filepath.Join("..", "..", "namespace", name)
+		rdfParser, err := rdfloader.LoadFromFilePath(filepath.Join(dir, name+".rdf"))
+		if err != nil {
+			t.Errorf("unexpected error loading the ontology of %v: %v", name, err)
+			continue
+		}
+		terms, nsStart, err := collectTerms(rdfParser.Triples, ns)
+		if err != nil {
+			t.Errorf("unexpected error collecting the terms of %v: %v", name, err)
+			continue
+		}
+		src, err := generate(name, name+".rdf", nsStart, terms)
+		if err != nil {
+			t.Errorf("unexpected error generating %v: %v", name, err)
+			continue
+		}
+		existing, err := ioutil.ReadFile(filepath.Join(dir, name+".go"))
+		if err != nil {
+			t.Errorf("unexpected error reading the package %v: %v", name, err)
+			continue
+		}
+		if string(existing) != string(src) {
+			t.Errorf("package %v is out of date. Run go generate ./namespace/...", name)
+		}
+	}
+}
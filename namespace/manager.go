@@ -0,0 +1,217 @@
+package namespace
+
+/*
+Provides a Manager which binds the prefixes to the namespaces.
+USAGE:
+	>>> manager := namespace.NewManager()
+	>>> spdxNS, _ := uri.NewURIRef("http://spdx.org/rdf/terms#")
+	>>> manager.Bind("spdx", spdxNS)
+	>>> manager.Expand("spdx:licenseId")
+	http://spdx.org/rdf/terms#licenseId, nil
+	>>> manager.Compact("http://spdx.org/rdf/terms#licenseId")
+	spdx:licenseId, nil
+*/
+
+import (
+	"fmt"
+	"github.com/spdx/gordf/uri"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Manager binds prefixes to namespaces. It expands curies like
+// spdx:licenseId to IRIs and compacts IRIs to curies.
+// Empty prefix is the default namespace. It is safe for concurrent use.
+type Manager struct {
+	lock sync.RWMutex
+	// maps a prefix to its namespace.
+	namespaces map[string]uri.URIRef
+	// maps the start of the IRIs of a namespace to the prefixes bound to
+	// the namespace in the order they are bound.
+	prefixes map[string][]string
+}
+
+// NewManager returns a Manager without any bindings.
+func NewManager() *Manager {
+	return &Manager{
+		namespaces: map[string]uri.URIRef{},
+		prefixes:   map[string][]string{},
+	}
+}
+
+// NewManagerFromBindings returns a Manager having the bindings of the map.
+// Prefixes are bound in their sorted order.
+func NewManagerFromBindings(bindings map[string]uri.URIRef) *Manager {
+	manager := NewManager()
+	prefixes := make([]string, 0, len(bindings))
+	for prefix := range bindings {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		// keys of a map are unique. Bindings never conflict.
+		manager.Bind(prefix, bindings[prefix])
+	}
+	return manager
+}
+
+// Clone returns a copy of the manager. Binding prefixes to the copy doesn't
+// change the bindings of the manager.
+func (manager *Manager) Clone() *Manager {
+	manager.lock.RLock()
+	defer manager.lock.RUnlock()
+	clone := NewManager()
+	for prefix, namespace := range manager.namespaces {
+		clone.namespaces[prefix] = namespace
+	}
+	for start, prefixes := range manager.prefixes {
+		clone.prefixes[start] = append([]string(nil), prefixes...)
+	}
+	return clone
+}
+
+// returns the start of the IRIs of the names in the namespace. It is the
+// namespace with a # char at the end if the namespace ends in neither
+// # nor /.
+func iriStart(namespace uri.URIRef) string {
+	start, err := namespace.AddFragment("")
+	if err != nil {
+		return namespace.String()
+	}
+	return start.String()
+}
+
+// Bind binds the prefix to the namespace. Binding a prefix again to the same
+// namespace does nothing. Returns an error if the prefix is already bound to
+// another namespace.
+// A namespace can be bound to more than one prefix. The first prefix is used
+// for compacting the IRIs of the namespace.
+func (manager *Manager) Bind(prefix string, namespace uri.URIRef) error {
+	manager.lock.Lock()
+	defer manager.lock.Unlock()
+	if existing, bound := manager.namespaces[prefix]; bound {
+		if existing.Equal(namespace) {
+			return nil
+		}
+		return fmt.Errorf("prefix %q is already bound to %v. Can't bind it to %v", prefix, existing.String(), namespace.String())
+	}
+	manager.namespaces[prefix] = namespace
+	start := iriStart(namespace)
+	manager.prefixes[start] = append(manager.prefixes[start], prefix)
+	return nil
+}
+
+// Namespace returns the namespace bound to the prefix.
+func (manager *Manager) Namespace(prefix string) (namespace uri.URIRef, bound bool) {
+	manager.lock.RLock()
+	defer manager.lock.RUnlock()
+	namespace, bound = manager.namespaces[prefix]
+	return namespace, bound
+}
+
+// Prefix returns the first prefix bound to the namespace.
+func (manager *Manager) Prefix(namespace uri.URIRef) (prefix string, bound bool) {
+	manager.lock.RLock()
+	defer manager.lock.RUnlock()
+	prefixes := manager.prefixes[iriStart(namespace)]
+	if len(prefixes) == 0 {
+		return "", false
+	}
+	return prefixes[0], true
+}
+
+// Bindings returns a copy of the bindings of the manager.
+func (manager *Manager) Bindings() map[string]uri.URIRef {
+	manager.lock.RLock()
+	defer manager.lock.RUnlock()
+	bindings := make(map[string]uri.URIRef, len(manager.namespaces))
+	for prefix, namespace := range manager.namespaces {
+		bindings[prefix] = namespace
+	}
+	return bindings
+}
+
+// Expand returns the IRI of a curie like spdx:licenseId. A curie without a
+// prefix is a name in the default namespace.
+func (manager *Manager) Expand(curie string) (uri.URIRef, error) {
+	prefix, name := "", curie
+	if idx := strings.Index(curie, ":"); idx != -1 {
+		prefix, name = curie[:idx], curie[idx+1:]
+	}
+	namespace, bound := manager.Namespace(prefix)
+	if !bound {
+		return uri.URIRef{}, fmt.Errorf("prefix %q of the curie %v is not bound to any namespace", prefix, curie)
+	}
+	return namespace.AddFragment(name)
+}
+
+// Compact returns the curie of the IRI using the longest namespace the IRI
+// starts with. The name of the curie is the rest of the IRI. It must be a
+// valid NCName so that the curie can be used as the name of a xml tag. A
+// name in the default namespace is returned without a prefix.
+func (manager *Manager) Compact(iri string) (string, error) {
+	manager.lock.RLock()
+	defer manager.lock.RUnlock()
+	longest := ""
+	for start := range manager.prefixes {
+		if len(start) <= len(longest) || !strings.HasPrefix(iri, start) {
+			continue
+		}
+		if !IsNCName(iri[len(start):]) {
+			continue
+		}
+		longest = start
+	}
+	if longest == "" {
+		return "", fmt.Errorf("no namespace of the uri %v is bound to a prefix", iri)
+	}
+	prefix, name := manager.prefixes[longest][0], iri[len(longest):]
+	if prefix == "" {
+		return name, nil
+	}
+	return prefix + ":" + name, nil
+}
+
+// IsNCName returns true if the name is a xml name without a colon. The
+// prefix and the local name of a qualified xml name are NCNames.
+// NCName = NameStartChar (NameChar)* excluding the ':' char.
+func IsNCName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !isNameStartChar(r) && (i == 0 || !isNameChar(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// returns true if the char can start a NCName.
+func isNameStartChar(r rune) bool {
+	switch {
+	case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '_':
+		return true
+	case 0xC0 <= r && r <= 0xD6, 0xD8 <= r && r <= 0xF6, 0xF8 <= r && r <= 0x2FF:
+		return true
+	case 0x370 <= r && r <= 0x37D, 0x37F <= r && r <= 0x1FFF, 0x200C <= r && r <= 0x200D:
+		return true
+	case 0x2070 <= r && r <= 0x218F, 0x2C00 <= r && r <= 0x2FEF, 0x3001 <= r && r <= 0xD7FF:
+		return true
+	case 0xF900 <= r && r <= 0xFDCF, 0xFDF0 <= r && r <= 0xFFFD, 0x10000 <= r && r <= 0xEFFFF:
+		return true
+	}
+	return false
+}
+
+// returns true if the char can be in a NCName after its first char.
+func isNameChar(r rune) bool {
+	switch {
+	case isNameStartChar(r), '0' <= r && r <= '9', r == '-', r == '.', r == 0xB7:
+		return true
+	case 0x300 <= r && r <= 0x36F, 0x203F <= r && r <= 0x2040:
+		return true
+	}
+	return false
+}
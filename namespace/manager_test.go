@@ -0,0 +1,199 @@
+package namespace
+
+import (
+	"github.com/spdx/gordf/uri"
+	"reflect"
+	"testing"
+)
+
+// returns a manager with the spdx, rdf and dcterms prefixes.
+func getSampleManager() *Manager {
+	manager := NewManager()
+	for prefix, namespace := range map[string]string{
+		"spdx":    "http://spdx.org/rdf/terms",
+		"rdf":     "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+		"dcterms": "http://purl.org/dc/terms/",
+	} {
+		uriref, _ := uri.NewURIRef(namespace)
+		manager.Bind(prefix, uriref)
+	}
+	return manager
+}
+
+func TestManager_Bind(t *testing.T) {
+	manager := getSampleManager()
+	spdxNS, _ := uri.NewURIRef("http://spdx.org/rdf/terms")
+	otherNS, _ := uri.NewURIRef("http://example.com/terms#")
+
+	// TestCase 1: binding a prefix again to the same namespace
+	if err := manager.Bind("spdx", spdxNS); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// TestCase 2: binding a prefix to another namespace must raise an error
+	if err := manager.Bind("spdx", otherNS); err == nil {
+		t.Errorf("expected an error stating the prefix is already bound")
+	}
+	if namespace, _ := manager.Namespace("spdx"); namespace.String() != spdxNS.String() {
+		t.Errorf("expected spdx to be bound to %v, found %v", spdxNS.String(), namespace.String())
+	}
+
+	// TestCase 3: a namespace bound to two prefixes is compacted using the
+	// first one.
+	if err := manager.Bind("spdx2", spdxNS); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if prefix, _ := manager.Prefix(spdxNS); prefix != "spdx" {
+		t.Errorf("expected spdx, found %v", prefix)
+	}
+
+	// TestCase 4: namespace with and without a trailing # is the same.
+	spdxHashNS, _ := uri.NewURIRef("http://spdx.org/rdf/terms#")
+	if prefix, bound := manager.Prefix(spdxHashNS); !bound || prefix != "spdx" {
+		t.Errorf("expected spdx, found %v", prefix)
+	}
+	if _, bound := manager.Prefix(otherNS); bound {
+		t.Errorf("expected %v to not be bound to any prefix", otherNS.String())
+	}
+}
+
+func TestNewManagerFromBindings(t *testing.T) {
+	spdxNS, _ := uri.NewURIRef("http://spdx.org/rdf/terms#")
+	bindings := map[string]uri.URIRef{"spdx": spdxNS, "a": spdxNS}
+	manager := NewManagerFromBindings(bindings)
+	if !reflect.DeepEqual(manager.Bindings(), bindings) {
+		t.Errorf("expected %v, found %v", bindings, manager.Bindings())
+	}
+	// prefixes are bound in the sorted order.
+	if prefix, _ := manager.Prefix(spdxNS); prefix != "a" {
+		t.Errorf("expected a, found %v", prefix)
+	}
+}
+
+func TestManager_Clone(t *testing.T) {
+	manager := getSampleManager()
+	clone := manager.Clone()
+	if !reflect.DeepEqual(clone.Bindings(), manager.Bindings()) {
+		t.Errorf("expected %v, found %v", manager.Bindings(), clone.Bindings())
+	}
+
+	// binding a prefix to the clone doesn't bind it to the manager.
+	spdxNS, _ := uri.NewURIRef("http://spdx.org/rdf/terms")
+	clone.Bind("spdx2", spdxNS)
+	if _, bound := manager.Namespace("spdx2"); bound {
+		t.Errorf("expected spdx2 to be bound only to the clone")
+	}
+	if prefix, _ := clone.Prefix(spdxNS); prefix != "spdx" {
+		t.Errorf("expected spdx, found %v", prefix)
+	}
+}
+
+func TestIsNCName(t *testing.T) {
+	names := map[string]bool{
+		"licenseId":              true,
+		"checksumAlgorithm_sha1": true,
+		"_1":                     true,
+		"a-b.c":                  true,
+		"été":                    true,
+		"":                       false,
+		"1st":                    false,
+		"-a":                     false,
+		".a":                     false,
+		"a:b":                    false,
+		"p?x=1":                  false,
+		"a b":                    false,
+	}
+	for name, expected := range names {
+		if IsNCName(name) != expected {
+			t.Errorf("expected IsNCName(%q) to be %v", name, expected)
+		}
+	}
+}
+
+func TestManager_Expand(t *testing.T) {
+	manager := getSampleManager()
+	curies := map[string]string{
+		"spdx:licenseId": "http://spdx.org/rdf/terms#licenseId",
+		"rdf:type":       "http://www.w3.org/1999/02/22-rdf-syntax-ns#type",
+		"dcterms:title":  "http://purl.org/dc/terms/title",
+	}
+	for curie, expected := range curies {
+		expanded, err := manager.Expand(curie)
+		if err != nil {
+			t.Errorf("unexpected error expanding %v: %v", curie, err)
+		}
+		if expanded.String() != expected {
+			t.Errorf("expected %v, found %v", expected, expanded.String())
+		}
+	}
+
+	// TestCase 2: unbound prefix must raise an error
+	if _, err := manager.Expand("ex:name"); err == nil {
+		t.Errorf("expected an error stating the prefix is not bound")
+	}
+	// curie without a prefix is expanded only if a default namespace is bound.
+	if _, err := manager.Expand("name"); err == nil {
+		t.Errorf("expected an error stating the default namespace is not bound")
+	}
+	defaultNS, _ := uri.NewURIRef("http://example.com/")
+	manager.Bind("", defaultNS)
+	if expanded, _ := manager.Expand("name"); expanded.String() != "http://example.com/name" {
+		t.Errorf("expected http://example.com/name, found %v", expanded.String())
+	}
+}
+
+func TestManager_Compact(t *testing.T) {
+	manager := getSampleManager()
+
+	// TestCase 1: uri doesn't have a name after the namespace
+	//             Must raise an error
+	if _, err := manager.Compact("http://spdx.org/rdf/terms#"); err == nil {
+		t.Errorf("didn't raise any error for url with no fragment")
+	}
+
+	// TestCase 2: uri with inexistent namespace
+	//             Must raise an error
+	if _, err := manager.Compact("https://www.googlge.com/terms#website"); err == nil {
+		t.Errorf("expected an error stating the namespace isn't bound")
+	}
+
+	// TestCase 3: valid uris of the hash and the slash namespaces
+	iris := map[string]string{
+		"http://spdx.org/rdf/terms#Snippet":               "spdx:Snippet",
+		"http://www.w3.org/1999/02/22-rdf-syntax-ns#type": "rdf:type",
+		"http://purl.org/dc/terms/title":                  "dcterms:title",
+	}
+	for iri, expected := range iris {
+		curie, err := manager.Compact(iri)
+		if err != nil {
+			t.Errorf("unexpected error compacting %v: %v", iri, err)
+		}
+		if curie != expected {
+			t.Errorf("expected %v, found %v", expected, curie)
+		}
+	}
+
+	// TestCase 4: longest namespace is used
+	licensesNS, _ := uri.NewURIRef("http://purl.org/dc/terms/licenses/")
+	manager.Bind("licenses", licensesNS)
+	if curie, _ := manager.Compact("http://purl.org/dc/terms/licenses/MIT"); curie != "licenses:MIT" {
+		t.Errorf("expected licenses:MIT, found %v", curie)
+	}
+	// name of a curie can't have a /
+	if _, err := manager.Compact("http://purl.org/dc/terms/a/b"); err == nil {
+		t.Errorf("expected an error for a name having a /")
+	}
+	// name of a curie must be a valid xml name.
+	for _, iri := range []string{"http://purl.org/dc/terms/p?x=1", "http://purl.org/dc/terms/1st", "http://purl.org/dc/terms/a:b", "http://purl.org/dc/terms/-a"} {
+		if curie, err := manager.Compact(iri); err == nil {
+			t.Errorf("expected an error for %v which isn't a valid xml name, found %v", iri, curie)
+		}
+	}
+
+	// TestCase 5: names in the default namespace don't have a prefix
+	defaultNS, _ := uri.NewURIRef("http://example.com/")
+	manager.Bind("", defaultNS)
+	if curie, _ := manager.Compact("http://example.com/name"); curie != "name" {
+		t.Errorf("expected name, found %v", curie)
+	}
+}
@@ -5,12 +5,14 @@ Provides a Namespace class.
 USAGE:
 	>>> ns, _ := namespace.New("https://spdx.org/rdf/terms")
     >>> ns.Get("d4e2952")
-    {https://spdx.org/rdf/terms#d4e2952}
+    https://spdx.org/rdf/terms#d4e2952, nil
 	>>> ns.Get("Tag")
-	{https://spdx.org/rdf/terms#Tag}
+	https://spdx.org/rdf/terms#Tag, nil
 
-	// Alternative initializations of namespace without abstraction
-	>> ns, _ := namespace.Namespace{uri.URIRef{"https://spdx.org/rdf/terms"}}
+	// names are added as the last segment of a namespace ending in /
+	>>> ns, _ := namespace.New("http://purl.org/dc/terms/")
+	>>> ns.Get("title")
+	http://purl.org/dc/terms/title, nil
 */
 
 import (
@@ -31,6 +33,7 @@ func New(namespace string) (ns Namespace, err error) {
 }
 
 // Appends a fragment string at the end of the namespace string.
-func (ns *Namespace) Get(fragment string) uri.URIRef {
+// Returns an error if the fragment doesn't result in a valid uri.
+func (ns *Namespace) Get(fragment string) (uri.URIRef, error) {
 	return ns.base.AddFragment(fragment)
 }
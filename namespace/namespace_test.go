@@ -28,8 +28,23 @@ func TestNamespace_Get(t *testing.T) {
 		return
 	}
 	fragment := "name"
-	indexedURI := sampleNS.Get(fragment)
+	indexedURI, err := sampleNS.Get(fragment)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
 	if indexedURI.String() != sampleURI+"#"+fragment {
 		t.Errorf("error adding fragment to the base URI")
 	}
+
+	// TestCase 2: names are added to the path of a slash namespace.
+	slashNS, _ := New("http://purl.org/dc/terms/")
+	indexedURI, _ = slashNS.Get("title")
+	if expected := "http://purl.org/dc/terms/title"; indexedURI.String() != expected {
+		t.Errorf("expected %v, found %v", expected, indexedURI.String())
+	}
+
+	// TestCase 3: invalid fragment must raise an error.
+	if _, err = sampleNS.Get("%%"); err == nil {
+		t.Errorf("expected an error for an invalid fragment")
+	}
 }
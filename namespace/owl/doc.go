@@ -0,0 +1,11 @@
+// Package owl provides the terms of the OWL vocabulary as constants.
+//
+//	iri := owl.SameAs.String() // http://www.w3.org/2002/07/owl#sameAs
+//
+// The constants are generated from owl.rdf, which is written by hand from
+// the vocabulary listed by the OWL 2 Mapping to RDF Graphs recommendation
+// (https://www.w3.org/TR/owl2-mapping-to-rdf/). It is not the ontology
+// published at the namespace and its comments are paraphrased.
+package owl
+
+//go:generate go run github.com/spdx/gordf/cmd/vocabgen -in owl.rdf -namespace http://www.w3.org/2002/07/owl# -package owl -out owl.go
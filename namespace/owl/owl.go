@@ -0,0 +1,286 @@
+// Code generated by vocabgen from owl.rdf. DO NOT EDIT.
+
+package owl
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://www.w3.org/2002/07/owl#"
+
+const (
+	// The class of collections of pairwise different individuals.
+	AllDifferent namespace.Term = NS + "AllDifferent"
+
+	// The class of collections of pairwise disjoint classes.
+	AllDisjointClasses namespace.Term = NS + "AllDisjointClasses"
+
+	// The class of collections of pairwise disjoint properties.
+	AllDisjointProperties namespace.Term = NS + "AllDisjointProperties"
+
+	// The class of annotated annotations for which the RDF serialization consists
+	// of an annotated subject, predicate and object.
+	Annotation namespace.Term = NS + "Annotation"
+
+	// The class of annotation properties.
+	AnnotationProperty namespace.Term = NS + "AnnotationProperty"
+
+	// The class of asymmetric properties.
+	AsymmetricProperty namespace.Term = NS + "AsymmetricProperty"
+
+	// The class of annotated axioms for which the RDF serialization consists of an
+	// annotated subject, predicate and object.
+	Axiom namespace.Term = NS + "Axiom"
+
+	// The class of OWL classes.
+	Class namespace.Term = NS + "Class"
+
+	// The class of OWL data ranges, which are special kinds of datatypes.
+	DataRange namespace.Term = NS + "DataRange"
+
+	// The class of data properties.
+	DatatypeProperty namespace.Term = NS + "DatatypeProperty"
+
+	// The class of deprecated classes.
+	DeprecatedClass namespace.Term = NS + "DeprecatedClass"
+
+	// The class of deprecated properties.
+	DeprecatedProperty namespace.Term = NS + "DeprecatedProperty"
+
+	// The class of functional properties.
+	FunctionalProperty namespace.Term = NS + "FunctionalProperty"
+
+	// The class of inverse-functional properties.
+	InverseFunctionalProperty namespace.Term = NS + "InverseFunctionalProperty"
+
+	// The class of irreflexive properties.
+	IrreflexiveProperty namespace.Term = NS + "IrreflexiveProperty"
+
+	// The class of named individuals.
+	NamedIndividual namespace.Term = NS + "NamedIndividual"
+
+	// The class of negative property assertions.
+	NegativePropertyAssertion namespace.Term = NS + "NegativePropertyAssertion"
+
+	// This is the empty class.
+	Nothing namespace.Term = NS + "Nothing"
+
+	// The class of object properties.
+	ObjectProperty namespace.Term = NS + "ObjectProperty"
+
+	// The class of ontologies.
+	Ontology namespace.Term = NS + "Ontology"
+
+	// The class of ontology properties.
+	OntologyProperty namespace.Term = NS + "OntologyProperty"
+
+	// The class of reflexive properties.
+	ReflexiveProperty namespace.Term = NS + "ReflexiveProperty"
+
+	// The class of property restrictions.
+	Restriction namespace.Term = NS + "Restriction"
+
+	// The class of symmetric properties.
+	SymmetricProperty namespace.Term = NS + "SymmetricProperty"
+
+	// The class of OWL individuals.
+	Thing namespace.Term = NS + "Thing"
+
+	// The class of transitive properties.
+	TransitiveProperty namespace.Term = NS + "TransitiveProperty"
+
+	// The property that determines the class that a universal property restriction
+	// refers to.
+	AllValuesFrom namespace.Term = NS + "allValuesFrom"
+
+	// The property that determines the predicate of an annotated axiom or
+	// annotated annotation.
+	AnnotatedProperty namespace.Term = NS + "annotatedProperty"
+
+	// The property that determines the subject of an annotated axiom or annotated
+	// annotation.
+	AnnotatedSource namespace.Term = NS + "annotatedSource"
+
+	// The property that determines the object of an annotated axiom or annotated
+	// annotation.
+	AnnotatedTarget namespace.Term = NS + "annotatedTarget"
+
+	// The property that determines the predicate of a negative property assertion.
+	AssertionProperty namespace.Term = NS + "assertionProperty"
+
+	// The annotation property that indicates that a given ontology is backward
+	// compatible with another ontology.
+	BackwardCompatibleWith namespace.Term = NS + "backwardCompatibleWith"
+
+	// The data property that does not relate any individual to any data value.
+	BottomDataProperty namespace.Term = NS + "bottomDataProperty"
+
+	// The object property that does not relate any two individuals.
+	BottomObjectProperty namespace.Term = NS + "bottomObjectProperty"
+
+	// The property that determines the cardinality of an exact cardinality
+	// restriction.
+	Cardinality namespace.Term = NS + "cardinality"
+
+	// The property that determines that a given class is the complement of another
+	// class.
+	ComplementOf namespace.Term = NS + "complementOf"
+
+	// The property that determines that a given data range is the complement of
+	// another data range with respect to the data domain.
+	DatatypeComplementOf namespace.Term = NS + "datatypeComplementOf"
+
+	// The annotation property that indicates that a given entity has been
+	// deprecated.
+	Deprecated namespace.Term = NS + "deprecated"
+
+	// The property that determines that two given individuals are different.
+	DifferentFrom namespace.Term = NS + "differentFrom"
+
+	// The property that determines that a given class is equivalent to the
+	// disjoint union of a collection of other classes.
+	DisjointUnionOf namespace.Term = NS + "disjointUnionOf"
+
+	// The property that determines that two given classes are disjoint.
+	DisjointWith namespace.Term = NS + "disjointWith"
+
+	// The property that determines the collection of pairwise different
+	// individuals in a owl:AllDifferent axiom.
+	DistinctMembers namespace.Term = NS + "distinctMembers"
+
+	// The property that determines that two given classes are equivalent, and that
+	// is used to specify datatype definitions.
+	EquivalentClass namespace.Term = NS + "equivalentClass"
+
+	// The property that determines that two given properties are equivalent.
+	EquivalentProperty namespace.Term = NS + "equivalentProperty"
+
+	// The property that determines the collection of properties that jointly build
+	// a key.
+	HasKey namespace.Term = NS + "hasKey"
+
+	// The property that determines the property that a self restriction refers to.
+	HasSelf namespace.Term = NS + "hasSelf"
+
+	// The property that determines the individual that a has-value restriction
+	// refers to.
+	HasValue namespace.Term = NS + "hasValue"
+
+	// The property that is used for importing other ontologies into a given
+	// ontology.
+	Imports namespace.Term = NS + "imports"
+
+	// The annotation property that indicates that a given ontology is incompatible
+	// with another ontology.
+	IncompatibleWith namespace.Term = NS + "incompatibleWith"
+
+	// The property that determines the collection of classes or data ranges that
+	// build an intersection.
+	IntersectionOf namespace.Term = NS + "intersectionOf"
+
+	// The property that determines that two given properties are inverse.
+	InverseOf namespace.Term = NS + "inverseOf"
+
+	// The property that determines the cardinality of a maximum cardinality
+	// restriction.
+	MaxCardinality namespace.Term = NS + "maxCardinality"
+
+	// The property that determines the cardinality of a maximum qualified
+	// cardinality restriction.
+	MaxQualifiedCardinality namespace.Term = NS + "maxQualifiedCardinality"
+
+	// The property that determines the collection of members in either a
+	// owl:AllDifferent, owl:AllDisjointClasses or owl:AllDisjointProperties axiom.
+	Members namespace.Term = NS + "members"
+
+	// The property that determines the cardinality of a minimum cardinality
+	// restriction.
+	MinCardinality namespace.Term = NS + "minCardinality"
+
+	// The property that determines the cardinality of a minimum qualified
+	// cardinality restriction.
+	MinQualifiedCardinality namespace.Term = NS + "minQualifiedCardinality"
+
+	// The property that determines the class that a qualified object cardinality
+	// restriction refers to.
+	OnClass namespace.Term = NS + "onClass"
+
+	// The property that determines the data range that a qualified data
+	// cardinality restriction refers to.
+	OnDataRange namespace.Term = NS + "onDataRange"
+
+	// The property that determines the datatype that a datatype restriction refers
+	// to.
+	OnDatatype namespace.Term = NS + "onDatatype"
+
+	// The property that determines the n-tuple of properties that a property
+	// restriction on an n-ary data range refers to.
+	OnProperties namespace.Term = NS + "onProperties"
+
+	// The property that determines the property that a property restriction refers
+	// to.
+	OnProperty namespace.Term = NS + "onProperty"
+
+	// The property that determines the collection of individuals or data values
+	// that build an enumeration.
+	OneOf namespace.Term = NS + "oneOf"
+
+	// The annotation property that indicates the predecessor ontology of a given
+	// ontology.
+	PriorVersion namespace.Term = NS + "priorVersion"
+
+	// The property that determines the n-tuple of properties that build a sub
+	// property chain of a given property.
+	PropertyChainAxiom namespace.Term = NS + "propertyChainAxiom"
+
+	// The property that determines that two given properties are disjoint.
+	PropertyDisjointWith namespace.Term = NS + "propertyDisjointWith"
+
+	// The property that determines the cardinality of an exact qualified
+	// cardinality restriction.
+	QualifiedCardinality namespace.Term = NS + "qualifiedCardinality"
+
+	// The datatype of the rational numbers.
+	Rational namespace.Term = NS + "rational"
+
+	// The datatype of the real numbers.
+	Real namespace.Term = NS + "real"
+
+	// The property that determines that two given individuals are equal.
+	SameAs namespace.Term = NS + "sameAs"
+
+	// The property that determines the class that an existential property
+	// restriction refers to.
+	SomeValuesFrom namespace.Term = NS + "someValuesFrom"
+
+	// The property that determines the subject of a negative property assertion.
+	SourceIndividual namespace.Term = NS + "sourceIndividual"
+
+	// The property that determines the object of a negative object property
+	// assertion.
+	TargetIndividual namespace.Term = NS + "targetIndividual"
+
+	// The property that determines the value of a negative data property
+	// assertion.
+	TargetValue namespace.Term = NS + "targetValue"
+
+	// The data property that relates every individual to every data value.
+	TopDataProperty namespace.Term = NS + "topDataProperty"
+
+	// The object property that relates every two individuals.
+	TopObjectProperty namespace.Term = NS + "topObjectProperty"
+
+	// The property that determines the collection of classes or data ranges that
+	// build a union.
+	UnionOf namespace.Term = NS + "unionOf"
+
+	// The property that identifies the version IRI of an ontology.
+	VersionIRI namespace.Term = NS + "versionIRI"
+
+	// The annotation property that provides version information for an ontology or
+	// another OWL construct.
+	VersionInfo namespace.Term = NS + "versionInfo"
+
+	// The property that determines the collection of facet-value pairs that define
+	// a datatype restriction.
+	WithRestrictions namespace.Term = NS + "withRestrictions"
+)
@@ -0,0 +1,13 @@
+// Package rdf provides the terms of the RDF vocabulary as constants.
+//
+//	iri := rdf.Type.String() // http://www.w3.org/1999/02/22-rdf-syntax-ns#type
+//
+// The constants are generated from rdf.rdf, which is not the ontology
+// published at the namespace. It is written by hand from the terms defined
+// by the RDF 1.1 Concepts (https://www.w3.org/TR/rdf11-concepts/), RDF
+// Schema 1.1 (https://www.w3.org/TR/rdf-schema/) and RDF/XML Syntax
+// (https://www.w3.org/TR/rdf-syntax-grammar/) recommendations. Its comments
+// are paraphrased from the recommendations.
+package rdf
+
+//go:generate go run github.com/spdx/gordf/cmd/vocabgen -in rdf.rdf -namespace http://www.w3.org/1999/02/22-rdf-syntax-ns# -package rdf -out rdf.go
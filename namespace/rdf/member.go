@@ -0,0 +1,29 @@
+package rdf
+
+import (
+	"fmt"
+	"github.com/spdx/gordf/namespace"
+	"strconv"
+	"strings"
+)
+
+// Member returns the container membership property rdf:_n. It is the
+// predicate of the n-th member of a rdf:Bag, rdf:Seq or rdf:Alt. Members are
+// numbered from 1.
+func Member(n int) namespace.Term {
+	return namespace.Term(fmt.Sprintf("%s_%d", NS, n))
+}
+
+// MemberIndex returns n if the iri is a container membership property
+// rdf:_n. ok is false for every other iri.
+func MemberIndex(iri string) (n int, ok bool) {
+	if !strings.HasPrefix(iri, NS+"_") {
+		return 0, false
+	}
+	suffix := strings.TrimPrefix(iri, NS+"_")
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n < 1 || strconv.Itoa(n) != suffix {
+		return 0, false
+	}
+	return n, true
+}
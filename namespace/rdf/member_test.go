@@ -0,0 +1,26 @@
+package rdf
+
+import (
+	"testing"
+)
+
+func TestMember(t *testing.T) {
+	if member := Member(3).String(); member != "http://www.w3.org/1999/02/22-rdf-syntax-ns#_3" {
+		t.Errorf("expected rdf:_3, found %v", member)
+	}
+}
+
+func TestMemberIndex(t *testing.T) {
+	// TestCase 1: rdf:_n is a container membership property.
+	n, ok := MemberIndex(Member(12).String())
+	if !ok || n != 12 {
+		t.Errorf("expected 12, true. found %v, %v", n, ok)
+	}
+
+	// TestCase 2: other iris are not container membership properties.
+	for _, iri := range []string{NS + "_0", NS + "_01", NS + "_a", Li.String(), "http://spdx.org/rdf/terms#_1"} {
+		if _, ok := MemberIndex(iri); ok {
+			t.Errorf("%v must not be a container membership property", iri)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Code generated by vocabgen from rdf.rdf. DO NOT EDIT.
+
+package rdf
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+const (
+	// The class of containers of alternatives.
+	Alt namespace.Term = NS + "Alt"
+
+	// The class of unordered containers.
+	Bag namespace.Term = NS + "Bag"
+
+	// The element of a node without a type in an RDF/XML document.
+	Description namespace.Term = NS + "Description"
+
+	// The datatype of RDF literals storing fragments of HTML content.
+	HTML namespace.Term = NS + "HTML"
+
+	// The attribute giving the IRI of a node relative to the base IRI of an
+	// RDF/XML document.
+	ID namespace.Term = NS + "ID"
+
+	// The class of RDF Lists.
+	List namespace.Term = NS + "List"
+
+	// The class of plain (i.e. untyped) literal values, as used in RIF and OWL 2.
+	PlainLiteral namespace.Term = NS + "PlainLiteral"
+
+	// The class of RDF properties.
+	Property namespace.Term = NS + "Property"
+
+	// The root element of an RDF/XML document.
+	RDF namespace.Term = NS + "RDF"
+
+	// The class of ordered containers.
+	Seq namespace.Term = NS + "Seq"
+
+	// The class of RDF statements.
+	Statement namespace.Term = NS + "Statement"
+
+	// The datatype of XML literal values.
+	XMLLiteral namespace.Term = NS + "XMLLiteral"
+
+	// The attribute giving the IRI of a node in an RDF/XML document.
+	About namespace.Term = NS + "about"
+
+	// The attribute giving the datatype of a typed literal in an RDF/XML document.
+	Datatype namespace.Term = NS + "datatype"
+
+	// The first item in the subject RDF list.
+	First namespace.Term = NS + "first"
+
+	// The datatype of language-tagged string values.
+	LangString namespace.Term = NS + "langString"
+
+	// The element of a member of a container in an RDF/XML document.
+	Li namespace.Term = NS + "li"
+
+	// The empty list, with no items in it.
+	Nil namespace.Term = NS + "nil"
+
+	// The attribute giving the label of a blank node in an RDF/XML document.
+	NodeID namespace.Term = NS + "nodeID"
+
+	// The object of the subject RDF statement.
+	Object namespace.Term = NS + "object"
+
+	// The attribute giving how the content of a property element of an RDF/XML
+	// document is parsed.
+	ParseType namespace.Term = NS + "parseType"
+
+	// The predicate of the subject RDF statement.
+	Predicate namespace.Term = NS + "predicate"
+
+	// The attribute giving the IRI of the object of a property element in an
+	// RDF/XML document.
+	Resource namespace.Term = NS + "resource"
+
+	// The rest of the subject RDF list after the first item.
+	Rest namespace.Term = NS + "rest"
+
+	// The subject of the subject RDF statement.
+	Subject namespace.Term = NS + "subject"
+
+	// The subject is an instance of a class.
+	Type namespace.Term = NS + "type"
+
+	// Idiomatic property used for structured values.
+	Value namespace.Term = NS + "value"
+)
@@ -0,0 +1,11 @@
+// Package rdfs provides the terms of the RDF Schema vocabulary as constants.
+//
+//	iri := rdfs.SubClassOf.String() // http://www.w3.org/2000/01/rdf-schema#subClassOf
+//
+// The constants are generated from rdfs.rdf, which is written by hand from
+// the RDF Schema 1.1 recommendation (https://www.w3.org/TR/rdf-schema/).
+// It is not the ontology published at the namespace and its comments are
+// paraphrased from the recommendation.
+package rdfs
+
+//go:generate go run github.com/spdx/gordf/cmd/vocabgen -in rdfs.rdf -namespace http://www.w3.org/2000/01/rdf-schema# -package rdfs -out rdfs.go
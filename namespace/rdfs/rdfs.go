@@ -0,0 +1,56 @@
+// Code generated by vocabgen from rdfs.rdf. DO NOT EDIT.
+
+package rdfs
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://www.w3.org/2000/01/rdf-schema#"
+
+const (
+	// The class of classes.
+	Class namespace.Term = NS + "Class"
+
+	// The class of RDF containers.
+	Container namespace.Term = NS + "Container"
+
+	// The class of container membership properties, rdf:_1, rdf:_2, ..., all of
+	// which are sub-properties of 'member'.
+	ContainerMembershipProperty namespace.Term = NS + "ContainerMembershipProperty"
+
+	// The class of RDF datatypes.
+	Datatype namespace.Term = NS + "Datatype"
+
+	// The class of literal values, eg. textual strings and integers.
+	Literal namespace.Term = NS + "Literal"
+
+	// The class resource, everything.
+	Resource namespace.Term = NS + "Resource"
+
+	// A description of the subject resource.
+	Comment namespace.Term = NS + "comment"
+
+	// A domain of the subject property.
+	Domain namespace.Term = NS + "domain"
+
+	// The definition of the subject resource.
+	IsDefinedBy namespace.Term = NS + "isDefinedBy"
+
+	// A human-readable name for the subject.
+	Label namespace.Term = NS + "label"
+
+	// A member of the subject resource.
+	Member namespace.Term = NS + "member"
+
+	// A range of the subject property.
+	Range namespace.Term = NS + "range"
+
+	// Further information about the subject resource.
+	SeeAlso namespace.Term = NS + "seeAlso"
+
+	// The subject is a subclass of a class.
+	SubClassOf namespace.Term = NS + "subClassOf"
+
+	// The subject is a subproperty of a property.
+	SubPropertyOf namespace.Term = NS + "subPropertyOf"
+)
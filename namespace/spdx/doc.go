@@ -0,0 +1,15 @@
+// Package spdx provides the terms of the SPDX vocabulary as constants.
+//
+// The list of terms is curated by hand. The constants are generated from
+// spdx.rdf, which is not the SPDX ontology published at
+// http://spdx.org/rdf/terms. It is written from the classes, properties and
+// individuals of the SPDX 2.3 specification
+// (https://spdx.github.io/spdx-spec/v2.3/) used by SPDX documents in the
+// RDF/XML format, and its comments are shortened from the descriptions of
+// the specification. The tests check the constants against the term list of
+// the specification.
+//
+//	iri := spdx.LicenseId.String() // http://spdx.org/rdf/terms#licenseId
+package spdx
+
+//go:generate go run github.com/spdx/gordf/cmd/vocabgen -in spdx.rdf -namespace http://spdx.org/rdf/terms# -package spdx -out spdx.go
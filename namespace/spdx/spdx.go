@@ -0,0 +1,539 @@
+// Code generated by vocabgen from spdx.rdf. DO NOT EDIT.
+
+package spdx
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://spdx.org/rdf/terms#"
+
+const (
+	// An Annotation is a comment on an SpdxItem by an agent.
+	Annotation namespace.Term = NS + "Annotation"
+
+	// The type of an Annotation.
+	AnnotationType namespace.Term = NS + "AnnotationType"
+
+	// The AnyLicenseInfo class includes all resources that represent licensing
+	// information.
+	AnyLicenseInfo namespace.Term = NS + "AnyLicenseInfo"
+
+	// A Checksum is value that allows the contents of a file to be authenticated.
+	Checksum namespace.Term = NS + "Checksum"
+
+	// The algorithm used to produce a Checksum.
+	ChecksumAlgorithm namespace.Term = NS + "ChecksumAlgorithm"
+
+	// A ConjunctiveLicenseSet represents a set of licensing information all of
+	// which apply.
+	ConjunctiveLicenseSet namespace.Term = NS + "ConjunctiveLicenseSet"
+
+	// One instance is required for each SPDX file produced.
+	CreationInfo namespace.Term = NS + "CreationInfo"
+
+	// Cross reference details for the a URL reference.
+	CrossRef namespace.Term = NS + "CrossRef"
+
+	// A DisjunctiveLicenseSet represents a set of licensing information where only
+	// one license applies at a time.
+	DisjunctiveLicenseSet namespace.Term = NS + "DisjunctiveLicenseSet"
+
+	// Information about an external SPDX document reference including the
+	// checksum.
+	ExternalDocumentRef namespace.Term = NS + "ExternalDocumentRef"
+
+	// An External Reference allows a Package to reference an external source of
+	// additional information, metadata, enumerations, asset identifiers, or
+	// downloadable content believed to be relevant to the Package.
+	ExternalRef namespace.Term = NS + "ExternalRef"
+
+	// An ExtractedLicensingInfo represents a license or licensing notice that was
+	// found in a package, file or snippet.
+	ExtractedLicensingInfo namespace.Term = NS + "ExtractedLicensingInfo"
+
+	// A File represents a named sequence of information that is contained in a
+	// software package.
+	File namespace.Term = NS + "File"
+
+	// The type of a File.
+	FileType namespace.Term = NS + "FileType"
+
+	// A License represents a copyright license.
+	License namespace.Term = NS + "License"
+
+	// An exception to a license.
+	LicenseException namespace.Term = NS + "LicenseException"
+
+	// A license which is included in the SPDX License List.
+	ListedLicense namespace.Term = NS + "ListedLicense"
+
+	// License exception specific to ListedLicenses.
+	ListedLicenseException namespace.Term = NS + "ListedLicenseException"
+
+	// A license with an or later operator indicating this license version or any
+	// later version of the license.
+	OrLaterOperator namespace.Term = NS + "OrLaterOperator"
+
+	// A Package represents a collection of software files that are delivered as a
+	// single functional component.
+	Package namespace.Term = NS + "Package"
+
+	// A manifest based verification code (the algorithm is defined in section 4.7
+	// of the full specification) of the SPDX Item.
+	PackageVerificationCode namespace.Term = NS + "PackageVerificationCode"
+
+	// Package purpose is defined in section 7.24 of the full specification.
+	Purpose namespace.Term = NS + "Purpose"
+
+	// Category used for ExternalRef.
+	ReferenceCategory namespace.Term = NS + "ReferenceCategory"
+
+	// Types used to external reference identifiers.
+	ReferenceType namespace.Term = NS + "ReferenceType"
+
+	// A Relationship represents a relationship between two SpdxElements.
+	Relationship namespace.Term = NS + "Relationship"
+
+	// The type of a Relationship.
+	RelationshipType namespace.Term = NS + "RelationshipType"
+
+	// This class has been deprecated in favor of an Annotation with an Annotation
+	// type of review.
+	Review namespace.Term = NS + "Review"
+
+	// The SimpleLicenseInfo class includes all resources that represent simple,
+	// atomic, licensing information.
+	SimpleLicensingInfo namespace.Term = NS + "SimpleLicensingInfo"
+
+	// The set of bytes in a file.
+	Snippet namespace.Term = NS + "Snippet"
+
+	// An SpdxDocument is a summary of the contents, provenance, ownership and
+	// licensing analysis of a specific software package.
+	SpdxDocument namespace.Term = NS + "SpdxDocument"
+
+	// An SpdxElement is any thing described in SPDX, either a document or an
+	// SpdxItem.
+	SpdxElement namespace.Term = NS + "SpdxElement"
+
+	// An SpdxItem is a potentially copyrightable work.
+	SpdxItem namespace.Term = NS + "SpdxItem"
+
+	// Sometimes a set of license terms apply except under special circumstances.
+	WithExceptionOperator namespace.Term = NS + "WithExceptionOperator"
+
+	// Identifies the algorithm used to produce the subject Checksum.
+	Algorithm namespace.Term = NS + "algorithm"
+
+	// Provide additional information about an SpdxElement.
+	AnnotationProperty namespace.Term = NS + "annotation"
+
+	// Identify when the comment was made.
+	AnnotationDate namespace.Term = NS + "annotationDate"
+
+	// Type of the annotation.
+	AnnotationTypeProperty namespace.Term = NS + "annotationType"
+	AnnotationType_other   namespace.Term = NS + "annotationType_other"
+	AnnotationType_review  namespace.Term = NS + "annotationType_review"
+
+	// This field identifies the person, organization, or tool that has commented
+	// on a file, package, snippet, or the entire document.
+	Annotator namespace.Term = NS + "annotator"
+
+	// Indicates the project in which the SpdxElement originated.
+	ArtifactOf namespace.Term = NS + "artifactOf"
+
+	// This field provides a place for the SPDX data creator to record
+	// acknowledgements that may be required to be communicated in some contexts.
+	AttributionText namespace.Term = NS + "attributionText"
+
+	// This field provides a place for recording the actual date the package was
+	// built.
+	BuiltDate namespace.Term = NS + "builtDate"
+
+	// The checksum property provides a mechanism that can be used to verify that
+	// the contents of a File or Package have not changed.
+	ChecksumProperty             namespace.Term = NS + "checksum"
+	ChecksumAlgorithm_adler32    namespace.Term = NS + "checksumAlgorithm_adler32"
+	ChecksumAlgorithm_blake2b256 namespace.Term = NS + "checksumAlgorithm_blake2b256"
+	ChecksumAlgorithm_blake2b384 namespace.Term = NS + "checksumAlgorithm_blake2b384"
+	ChecksumAlgorithm_blake2b512 namespace.Term = NS + "checksumAlgorithm_blake2b512"
+	ChecksumAlgorithm_blake3     namespace.Term = NS + "checksumAlgorithm_blake3"
+	ChecksumAlgorithm_md2        namespace.Term = NS + "checksumAlgorithm_md2"
+	ChecksumAlgorithm_md4        namespace.Term = NS + "checksumAlgorithm_md4"
+	ChecksumAlgorithm_md5        namespace.Term = NS + "checksumAlgorithm_md5"
+	ChecksumAlgorithm_md6        namespace.Term = NS + "checksumAlgorithm_md6"
+	ChecksumAlgorithm_sha1       namespace.Term = NS + "checksumAlgorithm_sha1"
+	ChecksumAlgorithm_sha224     namespace.Term = NS + "checksumAlgorithm_sha224"
+	ChecksumAlgorithm_sha256     namespace.Term = NS + "checksumAlgorithm_sha256"
+	ChecksumAlgorithm_sha384     namespace.Term = NS + "checksumAlgorithm_sha384"
+	ChecksumAlgorithm_sha3_256   namespace.Term = NS + "checksumAlgorithm_sha3_256"
+	ChecksumAlgorithm_sha3_384   namespace.Term = NS + "checksumAlgorithm_sha3_384"
+	ChecksumAlgorithm_sha3_512   namespace.Term = NS + "checksumAlgorithm_sha3_512"
+	ChecksumAlgorithm_sha512     namespace.Term = NS + "checksumAlgorithm_sha512"
+
+	// The checksumValue property provides a lower case hexidecimal encoded digest
+	// value produced using a specific algorithm.
+	ChecksumValue namespace.Term = NS + "checksumValue"
+
+	// Examples of the reference locator of an external reference of the
+	// ReferenceType.
+	ContextualExample namespace.Term = NS + "contextualExample"
+
+	// The text of copyright declarations recited in the package, file or snippet.
+	CopyrightText namespace.Term = NS + "copyrightText"
+
+	// Identify when the SPDX document was originally created.
+	Created namespace.Term = NS + "created"
+
+	// The creationInfo property relates an SpdxDocument to a set of information
+	// about the creation of the SpdxDocument.
+	CreationInfoProperty namespace.Term = NS + "creationInfo"
+
+	// Identify who (or what, in the case of a tool) created the SPDX document.
+	Creator namespace.Term = NS + "creator"
+
+	// Cross Reference Detail for a license SeeAlso URL.
+	CrossRefProperty namespace.Term = NS + "crossRef"
+
+	// Compliance with the SPDX specification includes populating the SPDX fields
+	// therein with data related to such fields ("SPDX-Metadata").
+	DataLicense namespace.Term = NS + "dataLicense"
+
+	// License List Version when a license ID is deprecated.
+	DeprecatedVersion namespace.Term = NS + "deprecatedVersion"
+
+	// The describesPackage property relates an SpdxDocument to the package which
+	// it describes.
+	DescribesPackage namespace.Term = NS + "describesPackage"
+
+	// Provides a detailed description of the package.
+	Description namespace.Term = NS + "description"
+
+	// Website having the documentation of a ReferenceType.
+	Documentation namespace.Term = NS + "documentation"
+
+	// The URI at which this package is available for download.
+	DownloadLocation namespace.Term = NS + "downloadLocation"
+
+	// Text for examples in describing an SPDX element.
+	Example namespace.Term = NS + "example"
+
+	// HTML representation of the text of a ListedLicenseException.
+	ExceptionTextHtml namespace.Term = NS + "exceptionTextHtml"
+
+	// Externally referenced SPDX Document Identifier.
+	ExternalDocumentId namespace.Term = NS + "externalDocumentId"
+
+	// Identify any external SPDX documents referenced within this SPDX document.
+	ExternalDocumentRefProperty namespace.Term = NS + "externalDocumentRef"
+
+	// An External Reference allows a Package to reference an external source of
+	// additional information, metadata, enumerations, asset identifiers, or
+	// downloadable content believed to be relevant to the Package.
+	ExternalRefProperty namespace.Term = NS + "externalRef"
+
+	// Website maintaining the identifiers of a ReferenceType.
+	ExternalReferenceSite namespace.Term = NS + "externalReferenceSite"
+
+	// Provide a copy of the actual text of the license reference extracted from
+	// the package, file or snippet that is associated with the License Identifier
+	// to aid in future analysis.
+	ExtractedText namespace.Term = NS + "extractedText"
+
+	// This field provides a place for the SPDX file creator to record file
+	// contributors.
+	FileContributor namespace.Term = NS + "fileContributor"
+
+	// This field is deprecated since SPDX 2.0 in favor of using Section 7 which
+	// provides more granularity about relationships.
+	FileDependency namespace.Term = NS + "fileDependency"
+
+	// The name of the file relative to the root of the package.
+	FileName namespace.Term = NS + "fileName"
+
+	// The type of the file.
+	FileTypeProperty       namespace.Term = NS + "fileType"
+	FileType_application   namespace.Term = NS + "fileType_application"
+	FileType_archive       namespace.Term = NS + "fileType_archive"
+	FileType_audio         namespace.Term = NS + "fileType_audio"
+	FileType_binary        namespace.Term = NS + "fileType_binary"
+	FileType_documentation namespace.Term = NS + "fileType_documentation"
+	FileType_image         namespace.Term = NS + "fileType_image"
+	FileType_other         namespace.Term = NS + "fileType_other"
+	FileType_source        namespace.Term = NS + "fileType_source"
+	FileType_spdx          namespace.Term = NS + "fileType_spdx"
+	FileType_text          namespace.Term = NS + "fileType_text"
+	FileType_video         namespace.Term = NS + "fileType_video"
+
+	// Indicates whether the file content of this package has been available for or
+	// subjected to analysis when creating the SPDX document.
+	FilesAnalyzed namespace.Term = NS + "filesAnalyzed"
+
+	// Indicates that a particular ExtractedLicensingInfo was defined in the
+	// subject SpdxDocument.
+	HasExtractedLicensingInfo namespace.Term = NS + "hasExtractedLicensingInfo"
+
+	// Indicates that a particular file belongs to a package.
+	HasFile namespace.Term = NS + "hasFile"
+
+	// True if the license ID is deprecated.
+	IsDeprecatedLicenseId namespace.Term = NS + "isDeprecatedLicenseId"
+
+	// Indicates if the FSF considers this license a free software license.
+	IsFsfLibre namespace.Term = NS + "isFsfLibre"
+
+	// Indicate a URL is still a live accessible location on the public internet.
+	IsLive namespace.Term = NS + "isLive"
+
+	// Indicates if the OSI has approved the license.
+	IsOsiApproved namespace.Term = NS + "isOsiApproved"
+
+	// True if the URL is a valid well formed URL.
+	IsValid namespace.Term = NS + "isValid"
+
+	// True if the License SeeAlsoUrl is one of the license URLs on the Internet
+	// Archive Wayback Machine.
+	IsWayBackLink namespace.Term = NS + "isWayBackLink"
+
+	// The licenseComments property allows the preparer of the SPDX document to
+	// describe why the licensing in spdx:licenseConcluded was chosen.
+	LicenseComments namespace.Term = NS + "licenseComments"
+
+	// The licensing that the preparer of this SPDX document has concluded, based
+	// on the evidence, actually applies to the SPDX Item.
+	LicenseConcluded namespace.Term = NS + "licenseConcluded"
+
+	// The licensing that the creators of the software in the package, or the
+	// packager, have declared.
+	LicenseDeclared namespace.Term = NS + "licenseDeclared"
+
+	// An exception to a license.
+	LicenseExceptionProperty namespace.Term = NS + "licenseException"
+
+	// Short form license exception identifier in Appendix I.2 of the SPDX
+	// specification.
+	LicenseExceptionId namespace.Term = NS + "licenseExceptionId"
+
+	// Template for matching license exception text.
+	LicenseExceptionTemplate namespace.Term = NS + "licenseExceptionTemplate"
+
+	// Full text of the license exception.
+	LicenseExceptionText namespace.Term = NS + "licenseExceptionText"
+
+	// A human readable short form license identifier for a license.
+	LicenseId namespace.Term = NS + "licenseId"
+
+	// The licensing information that was discovered directly within the package.
+	LicenseInfoFromFiles namespace.Term = NS + "licenseInfoFromFiles"
+
+	// Licensing information explicitly found in the file.
+	LicenseInfoInFile namespace.Term = NS + "licenseInfoInFile"
+
+	// Licensing information that was discovered directly in the subject snippet.
+	LicenseInfoInSnippet namespace.Term = NS + "licenseInfoInSnippet"
+
+	// An optional field for creators of the SPDX file to provide the version of
+	// the SPDX License List used when the SPDX file was created.
+	LicenseListVersion namespace.Term = NS + "licenseListVersion"
+
+	// Full text of the license.
+	LicenseText namespace.Term = NS + "licenseText"
+
+	// HTML representation of the text of a ListedLicense.
+	LicenseTextHtml namespace.Term = NS + "licenseTextHtml"
+
+	// Status of a License List SeeAlso URL reference if it refers to a website
+	// that matches the license text.
+	Match namespace.Term = NS + "match"
+
+	// A license, or other licensing information, that is a member of the subject
+	// license set.
+	Member namespace.Term = NS + "member"
+
+	// Identify name of this SpdxElement.
+	Name namespace.Term = NS + "name"
+
+	// Individual to indicate the creator of the SPDX document does not assert any
+	// value for the object.
+	Noassertion namespace.Term = NS + "noassertion"
+
+	// Individual to indicate that no value is applicable for the Object.
+	None namespace.Term = NS + "none"
+
+	// This field provides a place for the SPDX file creator to record potential
+	// legal notices found in the file.
+	NoticeText namespace.Term = NS + "noticeText"
+
+	// Specifies the licence IDs that replace a deprecated license.
+	ObsoletedBy namespace.Term = NS + "obsoletedBy"
+
+	// The ordinal order of this element within a list.
+	Order namespace.Term = NS + "order"
+
+	// The name and, optionally, contact information of the person or organization
+	// that originally created the package.
+	Originator namespace.Term = NS + "originator"
+
+	// The base name of the package file name.
+	PackageFileName namespace.Term = NS + "packageFileName"
+
+	// A manifest based verification code of the package.
+	PackageVerificationCodeProperty namespace.Term = NS + "packageVerificationCode"
+
+	// A file that was excluded when calculating the package verification code.
+	PackageVerificationCodeExcludedFile namespace.Term = NS + "packageVerificationCodeExcludedFile"
+
+	// The actual package verification code as a hex encoded value.
+	PackageVerificationCodeValue namespace.Term = NS + "packageVerificationCodeValue"
+
+	// This field provides information about the primary purpose of the identified
+	// package.
+	PrimaryPackagePurpose   namespace.Term = NS + "primaryPackagePurpose"
+	Purpose_application     namespace.Term = NS + "purpose_application"
+	Purpose_archive         namespace.Term = NS + "purpose_archive"
+	Purpose_container       namespace.Term = NS + "purpose_container"
+	Purpose_device          namespace.Term = NS + "purpose_device"
+	Purpose_file            namespace.Term = NS + "purpose_file"
+	Purpose_firmware        namespace.Term = NS + "purpose_firmware"
+	Purpose_framework       namespace.Term = NS + "purpose_framework"
+	Purpose_install         namespace.Term = NS + "purpose_install"
+	Purpose_library         namespace.Term = NS + "purpose_library"
+	Purpose_operatingSystem namespace.Term = NS + "purpose_operatingSystem"
+	Purpose_other           namespace.Term = NS + "purpose_other"
+	Purpose_source          namespace.Term = NS + "purpose_source"
+
+	// Range of bytes or lines of the file of a Snippet.
+	Range namespace.Term = NS + "range"
+
+	// Category for the external reference.
+	ReferenceCategoryProperty        namespace.Term = NS + "referenceCategory"
+	ReferenceCategory_other          namespace.Term = NS + "referenceCategory_other"
+	ReferenceCategory_packageManager namespace.Term = NS + "referenceCategory_packageManager"
+	ReferenceCategory_persistentId   namespace.Term = NS + "referenceCategory_persistentId"
+	ReferenceCategory_security       namespace.Term = NS + "referenceCategory_security"
+
+	// The unique string with no spaces necessary to access the package-specific
+	// information, metadata, or content within the target location.
+	ReferenceLocator namespace.Term = NS + "referenceLocator"
+
+	// Type of the external reference.
+	ReferenceTypeProperty namespace.Term = NS + "referenceType"
+
+	// A related SpdxElement.
+	RelatedSpdxElement namespace.Term = NS + "relatedSpdxElement"
+
+	// Defines a relationship between two SPDX elements.
+	RelationshipProperty namespace.Term = NS + "relationship"
+
+	// Describes the type of relationship between two SPDX elements.
+	RelationshipTypeProperty                   namespace.Term = NS + "relationshipType"
+	RelationshipType_amends                    namespace.Term = NS + "relationshipType_amends"
+	RelationshipType_ancestorOf                namespace.Term = NS + "relationshipType_ancestorOf"
+	RelationshipType_buildDependencyOf         namespace.Term = NS + "relationshipType_buildDependencyOf"
+	RelationshipType_buildToolOf               namespace.Term = NS + "relationshipType_buildToolOf"
+	RelationshipType_containedBy               namespace.Term = NS + "relationshipType_containedBy"
+	RelationshipType_contains                  namespace.Term = NS + "relationshipType_contains"
+	RelationshipType_copyOf                    namespace.Term = NS + "relationshipType_copyOf"
+	RelationshipType_dataFileOf                namespace.Term = NS + "relationshipType_dataFileOf"
+	RelationshipType_dependencyManifestOf      namespace.Term = NS + "relationshipType_dependencyManifestOf"
+	RelationshipType_dependencyOf              namespace.Term = NS + "relationshipType_dependencyOf"
+	RelationshipType_dependsOn                 namespace.Term = NS + "relationshipType_dependsOn"
+	RelationshipType_descendantOf              namespace.Term = NS + "relationshipType_descendantOf"
+	RelationshipType_describedBy               namespace.Term = NS + "relationshipType_describedBy"
+	RelationshipType_describes                 namespace.Term = NS + "relationshipType_describes"
+	RelationshipType_devDependencyOf           namespace.Term = NS + "relationshipType_devDependencyOf"
+	RelationshipType_devToolOf                 namespace.Term = NS + "relationshipType_devToolOf"
+	RelationshipType_distributionArtifact      namespace.Term = NS + "relationshipType_distributionArtifact"
+	RelationshipType_documentationOf           namespace.Term = NS + "relationshipType_documentationOf"
+	RelationshipType_dynamicLink               namespace.Term = NS + "relationshipType_dynamicLink"
+	RelationshipType_exampleOf                 namespace.Term = NS + "relationshipType_exampleOf"
+	RelationshipType_expandedFromArchive       namespace.Term = NS + "relationshipType_expandedFromArchive"
+	RelationshipType_fileAdded                 namespace.Term = NS + "relationshipType_fileAdded"
+	RelationshipType_fileDeleted               namespace.Term = NS + "relationshipType_fileDeleted"
+	RelationshipType_fileModified              namespace.Term = NS + "relationshipType_fileModified"
+	RelationshipType_generatedFrom             namespace.Term = NS + "relationshipType_generatedFrom"
+	RelationshipType_generates                 namespace.Term = NS + "relationshipType_generates"
+	RelationshipType_hasPrerequisite           namespace.Term = NS + "relationshipType_hasPrerequisite"
+	RelationshipType_metafileOf                namespace.Term = NS + "relationshipType_metafileOf"
+	RelationshipType_optionalComponentOf       namespace.Term = NS + "relationshipType_optionalComponentOf"
+	RelationshipType_optionalDependencyOf      namespace.Term = NS + "relationshipType_optionalDependencyOf"
+	RelationshipType_other                     namespace.Term = NS + "relationshipType_other"
+	RelationshipType_packageOf                 namespace.Term = NS + "relationshipType_packageOf"
+	RelationshipType_patchApplied              namespace.Term = NS + "relationshipType_patchApplied"
+	RelationshipType_patchFor                  namespace.Term = NS + "relationshipType_patchFor"
+	RelationshipType_prerequisiteFor           namespace.Term = NS + "relationshipType_prerequisiteFor"
+	RelationshipType_providedDependencyOf      namespace.Term = NS + "relationshipType_providedDependencyOf"
+	RelationshipType_requirementDescriptionFor namespace.Term = NS + "relationshipType_requirementDescriptionFor"
+	RelationshipType_runtimeDependencyOf       namespace.Term = NS + "relationshipType_runtimeDependencyOf"
+	RelationshipType_specificationFor          namespace.Term = NS + "relationshipType_specificationFor"
+	RelationshipType_staticLink                namespace.Term = NS + "relationshipType_staticLink"
+	RelationshipType_testDependencyOf          namespace.Term = NS + "relationshipType_testDependencyOf"
+	RelationshipType_testOf                    namespace.Term = NS + "relationshipType_testOf"
+	RelationshipType_testToolOf                namespace.Term = NS + "relationshipType_testToolOf"
+	RelationshipType_testcaseOf                namespace.Term = NS + "relationshipType_testcaseOf"
+	RelationshipType_variantOf                 namespace.Term = NS + "relationshipType_variantOf"
+
+	// This field provides a place for recording the date the package was released.
+	ReleaseDate namespace.Term = NS + "releaseDate"
+
+	// The date and time at which the SpdxDocument was reviewed.
+	ReviewDate namespace.Term = NS + "reviewDate"
+
+	// Reviewed.
+	Reviewed namespace.Term = NS + "reviewed"
+
+	// The name and, optionally, contact information of the person who performed
+	// the review.
+	Reviewer namespace.Term = NS + "reviewer"
+
+	// File containing the SPDX element (e.g. the file contaning a snippet).
+	SnippetFromFile namespace.Term = NS + "snippetFromFile"
+
+	// Allows the producer(s) of the SPDX document to describe how the package was
+	// acquired and/or changed from the original source.
+	SourceInfo namespace.Term = NS + "sourceInfo"
+
+	// A property containing an SPDX document.
+	SpdxDocumentProperty namespace.Term = NS + "spdxDocument"
+
+	// Provide a reference number that can be used to understand how to parse and
+	// interpret the rest of the file.
+	SpecVersion namespace.Term = NS + "specVersion"
+
+	// License author's preferred text to indicated that a file is covered by the
+	// license.
+	StandardLicenseHeader namespace.Term = NS + "standardLicenseHeader"
+
+	// HTML representation of the standard license header of a ListedLicense.
+	StandardLicenseHeaderHtml namespace.Term = NS + "standardLicenseHeaderHtml"
+
+	// License template which describes sections of the license header which can be
+	// varied.
+	StandardLicenseHeaderTemplate namespace.Term = NS + "standardLicenseHeaderTemplate"
+
+	// License template which describes sections of the license which can be
+	// varied.
+	StandardLicenseTemplate namespace.Term = NS + "standardLicenseTemplate"
+
+	// Provides a short description of the package.
+	Summary namespace.Term = NS + "summary"
+
+	// The name and, optionally, contact information of the person or organization
+	// who was the immediate supplier of this package to the recipient.
+	Supplier namespace.Term = NS + "supplier"
+
+	// Timestamp.
+	Timestamp namespace.Term = NS + "timestamp"
+
+	// URL Reference.
+	Url namespace.Term = NS + "url"
+
+	// This field provides a place for recording the end of the support period for
+	// a package from the supplier.
+	ValidUntilDate namespace.Term = NS + "validUntilDate"
+
+	// Provides an indication of the version of the package that is described by
+	// this SpdxDocument.
+	VersionInfo namespace.Term = NS + "versionInfo"
+)
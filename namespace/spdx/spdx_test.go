@@ -0,0 +1,148 @@
+package spdx
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+// names of the terms of the SPDX 2.3 specification used by the SPDX
+// documents in the RDF/XML format. The list is curated from the
+// specification like spdx.rdf. It must be updated with spdx.rdf when a term
+// is added to or removed from the vocabulary.
+func specTerms() []string {
+	return []string{
+		// classes of the SPDX model.
+		"Annotation", "AnnotationType", "AnyLicenseInfo", "Checksum",
+		"ChecksumAlgorithm", "ConjunctiveLicenseSet", "CreationInfo", "CrossRef",
+		"DisjunctiveLicenseSet", "ExternalDocumentRef", "ExternalRef", "ExtractedLicensingInfo",
+		"File", "FileType", "License", "LicenseException",
+		"ListedLicense", "ListedLicenseException", "OrLaterOperator", "Package",
+		"PackageVerificationCode", "Purpose", "ReferenceCategory", "ReferenceType",
+		"Relationship", "RelationshipType", "Review", "SimpleLicensingInfo",
+		"Snippet", "SpdxDocument", "SpdxElement", "SpdxItem",
+		"WithExceptionOperator",
+		// properties of the SPDX model.
+		"algorithm", "annotation", "annotationDate", "annotationType",
+		"annotator", "artifactOf", "attributionText", "builtDate",
+		"checksum", "checksumValue", "contextualExample", "copyrightText",
+		"created", "creationInfo", "creator", "crossRef",
+		"dataLicense", "deprecatedVersion", "describesPackage", "description",
+		"documentation", "downloadLocation", "example", "exceptionTextHtml",
+		"externalDocumentId", "externalDocumentRef", "externalRef", "externalReferenceSite",
+		"extractedText", "fileContributor", "fileDependency", "fileName",
+		"fileType", "filesAnalyzed", "hasExtractedLicensingInfo", "hasFile",
+		"isDeprecatedLicenseId", "isFsfLibre", "isLive", "isOsiApproved",
+		"isValid", "isWayBackLink", "licenseComments", "licenseConcluded",
+		"licenseDeclared", "licenseException", "licenseExceptionId", "licenseExceptionTemplate",
+		"licenseExceptionText", "licenseId", "licenseInfoFromFiles", "licenseInfoInFile",
+		"licenseInfoInSnippet", "licenseListVersion", "licenseText", "licenseTextHtml",
+		"match", "member", "name", "noticeText",
+		"obsoletedBy", "order", "originator", "packageFileName",
+		"packageVerificationCode", "packageVerificationCodeExcludedFile", "packageVerificationCodeValue", "primaryPackagePurpose",
+		"range", "referenceCategory", "referenceLocator", "referenceType",
+		"relatedSpdxElement", "relationship", "relationshipType", "releaseDate",
+		"reviewDate", "reviewed", "reviewer", "snippetFromFile",
+		"sourceInfo", "spdxDocument", "specVersion", "standardLicenseHeader",
+		"standardLicenseHeaderHtml", "standardLicenseHeaderTemplate", "standardLicenseTemplate", "summary",
+		"supplier", "timestamp", "url", "validUntilDate",
+		"versionInfo",
+		// annotation types.
+		"annotationType_other", "annotationType_review",
+		// checksum algorithms.
+		"checksumAlgorithm_adler32", "checksumAlgorithm_blake2b256", "checksumAlgorithm_blake2b384",
+		"checksumAlgorithm_blake2b512", "checksumAlgorithm_blake3", "checksumAlgorithm_md2",
+		"checksumAlgorithm_md4", "checksumAlgorithm_md5", "checksumAlgorithm_md6",
+		"checksumAlgorithm_sha1", "checksumAlgorithm_sha224", "checksumAlgorithm_sha256",
+		"checksumAlgorithm_sha384", "checksumAlgorithm_sha3_256", "checksumAlgorithm_sha3_384",
+		"checksumAlgorithm_sha3_512", "checksumAlgorithm_sha512",
+		// file types.
+		"fileType_application", "fileType_archive", "fileType_audio",
+		"fileType_binary", "fileType_documentation", "fileType_image",
+		"fileType_other", "fileType_source", "fileType_spdx",
+		"fileType_text", "fileType_video",
+		// primary package purposes.
+		"purpose_application", "purpose_archive", "purpose_container",
+		"purpose_device", "purpose_file", "purpose_firmware",
+		"purpose_framework", "purpose_install", "purpose_library",
+		"purpose_operatingSystem", "purpose_other", "purpose_source",
+		// categories of external references.
+		"referenceCategory_other", "referenceCategory_packageManager", "referenceCategory_persistentId",
+		"referenceCategory_security",
+		// relationship types.
+		"relationshipType_amends", "relationshipType_ancestorOf", "relationshipType_buildDependencyOf",
+		"relationshipType_buildToolOf", "relationshipType_containedBy", "relationshipType_contains",
+		"relationshipType_copyOf", "relationshipType_dataFileOf", "relationshipType_dependencyManifestOf",
+		"relationshipType_dependencyOf", "relationshipType_dependsOn", "relationshipType_descendantOf",
+		"relationshipType_describedBy", "relationshipType_describes", "relationshipType_devDependencyOf",
+		"relationshipType_devToolOf", "relationshipType_distributionArtifact", "relationshipType_documentationOf",
+		"relationshipType_dynamicLink", "relationshipType_exampleOf", "relationshipType_expandedFromArchive",
+		"relationshipType_fileAdded", "relationshipType_fileDeleted", "relationshipType_fileModified",
+		"relationshipType_generatedFrom", "relationshipType_generates", "relationshipType_hasPrerequisite",
+		"relationshipType_metafileOf", "relationshipType_optionalComponentOf", "relationshipType_optionalDependencyOf",
+		"relationshipType_other", "relationshipType_packageOf", "relationshipType_patchApplied",
+		"relationshipType_patchFor", "relationshipType_prerequisiteFor", "relationshipType_providedDependencyOf",
+		"relationshipType_requirementDescriptionFor", "relationshipType_runtimeDependencyOf", "relationshipType_specificationFor",
+		"relationshipType_staticLink", "relationshipType_testDependencyOf", "relationshipType_testOf",
+		"relationshipType_testToolOf", "relationshipType_testcaseOf", "relationshipType_variantOf",
+		// special values NOASSERTION and NONE.
+		"noassertion", "none",
+	}
+}
+
+// returns the names of the terms declared in spdx.go.
+func declaredTerms(t *testing.T) map[string]bool {
+	file, err := parser.ParseFile(token.NewFileSet(), "spdx.go", nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error parsing spdx.go: %v", err)
+	}
+	terms := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		// terms are declared as NS + "name".
+		expr, ok := node.(*ast.BinaryExpr)
+		if !ok {
+			return true
+		}
+		if literal, ok := expr.Y.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			name, err := strconv.Unquote(literal.Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			terms[name] = true
+		}
+		return true
+	})
+	return terms
+}
+
+func TestTerms(t *testing.T) {
+	declared := declaredTerms(t)
+	spec := map[string]bool{}
+	for _, name := range specTerms() {
+		if spec[name] {
+			t.Errorf("%v is listed more than once", name)
+		}
+		spec[name] = true
+	}
+
+	// TestCase 1: every term of the specification is in the vocabulary.
+	for name := range spec {
+		if !declared[name] {
+			t.Errorf("term %v of the specification is missing in spdx.go", name)
+		}
+	}
+
+	// TestCase 2: vocabulary has only the terms of the specification.
+	for name := range declared {
+		if !spec[name] {
+			t.Errorf("term %v of spdx.go is not a term of the specification", name)
+		}
+	}
+
+	// TestCase 3: iri of a term is the name in the namespace.
+	if iri := Range.String(); iri != "http://spdx.org/rdf/terms#range" {
+		t.Errorf("expected http://spdx.org/rdf/terms#range, found %v", iri)
+	}
+}
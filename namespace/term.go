@@ -0,0 +1,21 @@
+package namespace
+
+import (
+	"github.com/spdx/gordf/uri"
+)
+
+// Term is the IRI of a term of a vocabulary like rdf:type.
+// Vocabulary packages like namespace/rdf define their terms as constants of
+// this type. They are generated from the ontology of the vocabulary by the
+// cmd/vocabgen command.
+type Term string
+
+// returns the IRI of the term.
+func (term Term) String() string {
+	return string(term)
+}
+
+// URIRef returns the IRI of the term as a uri.URIRef.
+func (term Term) URIRef() (uri.URIRef, error) {
+	return uri.NewURIRef(string(term))
+}
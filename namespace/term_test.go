@@ -0,0 +1,19 @@
+package namespace
+
+import (
+	"testing"
+)
+
+func TestTerm_URIRef(t *testing.T) {
+	term := Term("http://spdx.org/rdf/terms#licenseId")
+	uriref, err := term.URIRef()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if uriref.String() != term.String() {
+		t.Errorf("expected %v, found %v", term.String(), uriref.String())
+	}
+	if uriref.Fragment() != "licenseId" {
+		t.Errorf("expected licenseId, found %v", uriref.Fragment())
+	}
+}
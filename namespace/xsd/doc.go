@@ -0,0 +1,12 @@
+// Package xsd provides the terms of the XML Schema datatypes vocabulary as constants.
+//
+//	iri := xsd.String.String() // http://www.w3.org/2001/XMLSchema#string
+//
+// The constants are generated from xsd.rdf, which is written by hand. It
+// has the built-in datatypes of XSD 1.1 Part 2
+// (https://www.w3.org/TR/xmlschema11-2/) listed as compatible with RDF by
+// RDF 1.1 Concepts (https://www.w3.org/TR/rdf11-concepts/#xsd-datatypes).
+// Its comments are paraphrased from the definitions of the datatypes.
+package xsd
+
+//go:generate go run github.com/spdx/gordf/cmd/vocabgen -in xsd.rdf -namespace http://www.w3.org/2001/XMLSchema# -package xsd -out xsd.go
@@ -0,0 +1,130 @@
+// Code generated by vocabgen from xsd.rdf. DO NOT EDIT.
+
+package xsd
+
+import "github.com/spdx/gordf/namespace"
+
+// NS is the namespace of the vocabulary.
+const NS = "http://www.w3.org/2001/XMLSchema#"
+
+const (
+	// The datatype of the XML names without a colon.
+	NCName namespace.Term = NS + "NCName"
+
+	// The datatype of the NMTOKEN attribute type of XML.
+	NMTOKEN namespace.Term = NS + "NMTOKEN"
+
+	// The datatype of the XML names.
+	Name namespace.Term = NS + "Name"
+
+	// The datatype of the URI references.
+	AnyURI namespace.Term = NS + "anyURI"
+
+	// The datatype of the Base64-encoded binary data.
+	Base64Binary namespace.Term = NS + "base64Binary"
+
+	// The datatype of the values of two-valued logic, true and false.
+	Boolean namespace.Term = NS + "boolean"
+
+	// The datatype of the integers from -128 to 127.
+	Byte namespace.Term = NS + "byte"
+
+	// The datatype of the calendar dates with an optional timezone.
+	Date namespace.Term = NS + "date"
+
+	// The datatype of the date and time values with an optional timezone.
+	DateTime namespace.Term = NS + "dateTime"
+
+	// The datatype of the date and time values with a required timezone.
+	DateTimeStamp namespace.Term = NS + "dateTimeStamp"
+
+	// The datatype of the durations having only the day, hour, minute and second
+	// components.
+	DayTimeDuration namespace.Term = NS + "dayTimeDuration"
+
+	// The datatype of the arbitrary precision decimal numbers.
+	Decimal namespace.Term = NS + "decimal"
+
+	// The datatype of the IEEE double-precision 64-bit floating point numbers.
+	Double namespace.Term = NS + "double"
+
+	// The datatype of the durations of time.
+	Duration namespace.Term = NS + "duration"
+
+	// The datatype of the IEEE single-precision 32-bit floating point numbers.
+	Float namespace.Term = NS + "float"
+
+	// The datatype of the recurring days of a month.
+	GDay namespace.Term = NS + "gDay"
+
+	// The datatype of the recurring months of a year.
+	GMonth namespace.Term = NS + "gMonth"
+
+	// The datatype of the recurring days of a year.
+	GMonthDay namespace.Term = NS + "gMonthDay"
+
+	// The datatype of the Gregorian calendar years.
+	GYear namespace.Term = NS + "gYear"
+
+	// The datatype of the Gregorian calendar months.
+	GYearMonth namespace.Term = NS + "gYearMonth"
+
+	// The datatype of the hex-encoded binary data.
+	HexBinary namespace.Term = NS + "hexBinary"
+
+	// The datatype of the integers from -2147483648 to 2147483647.
+	Int namespace.Term = NS + "int"
+
+	// The datatype of the arbitrary size integers.
+	Integer namespace.Term = NS + "integer"
+
+	// The datatype of the language tags of BCP 47.
+	Language namespace.Term = NS + "language"
+
+	// The datatype of the integers from -9223372036854775808 to
+	// 9223372036854775807.
+	Long namespace.Term = NS + "long"
+
+	// The datatype of the integers less than 0.
+	NegativeInteger namespace.Term = NS + "negativeInteger"
+
+	// The datatype of the integers greater than or equal to 0.
+	NonNegativeInteger namespace.Term = NS + "nonNegativeInteger"
+
+	// The datatype of the integers less than or equal to 0.
+	NonPositiveInteger namespace.Term = NS + "nonPositiveInteger"
+
+	// The datatype of the strings without carriage returns, line feeds and tabs.
+	NormalizedString namespace.Term = NS + "normalizedString"
+
+	// The datatype of the integers greater than 0.
+	PositiveInteger namespace.Term = NS + "positiveInteger"
+
+	// The datatype of the integers from -32768 to 32767.
+	Short namespace.Term = NS + "short"
+
+	// The datatype of the character strings.
+	String namespace.Term = NS + "string"
+
+	// The datatype of the times of a day with an optional timezone.
+	Time namespace.Term = NS + "time"
+
+	// The datatype of the normalized strings without leading, trailing and
+	// consecutive spaces.
+	Token namespace.Term = NS + "token"
+
+	// The datatype of the integers from 0 to 255.
+	UnsignedByte namespace.Term = NS + "unsignedByte"
+
+	// The datatype of the integers from 0 to 4294967295.
+	UnsignedInt namespace.Term = NS + "unsignedInt"
+
+	// The datatype of the integers from 0 to 18446744073709551615.
+	UnsignedLong namespace.Term = NS + "unsignedLong"
+
+	// The datatype of the integers from 0 to 65535.
+	UnsignedShort namespace.Term = NS + "unsignedShort"
+
+	// The datatype of the durations having only the year and month components.
+	YearMonthDuration namespace.Term = NS + "yearMonthDuration"
+)
@@ -1,41 +1,79 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
 
 type NODETYPE string
 
 const (
 	LITERAL         NODETYPE = "LITERAL"
 	RESOURCELITERAL          = "RESOURCE"
-	NODEIDLITERAL            = "NodeIDLiteral"
-	BLANK                    = "BNODE"
-	IRI                      = "IRI"
+	// Deprecated: blank nodes given by rdf:nodeID are BLANK nodes.
+	// The parser never generates a NODEIDLITERAL node.
+	NODEIDLITERAL = "NodeIDLiteral"
+	BLANK         = "BNODE"
+	IRI           = "IRI"
 )
 
+// prefix of the ID of the blank nodes labelled by the rdf:nodeID attribute
+// of the document. IDs of the blank nodes generated by the BlankNodeGetter
+// never have it. So, a generated blank node is never same as a blank node
+// labelled by the document.
+const NodeIDPrefix = "_:"
+
 type Node struct {
 	NodeType NODETYPE
 	ID       string
+	// IRI of the datatype of a typed literal given by the rdf:datatype attribute.
+	// it is empty for plain literals and for non-literal nodes.
+	DataType string
+	// language tag of a plain literal given by the xml:lang attribute in scope.
+	// it is empty for literals without a language and for non-literal nodes.
+	Lang string
 }
 
 func (node *Node) String() string {
+	switch {
+	case node.DataType != "":
+		// typed literals are different from plain literals with same value.
+		return fmt.Sprintf("(%v, %v, %v)", node.NodeType, node.ID, node.DataType)
+	case node.Lang != "":
+		// literals with same value but different languages are different.
+		return fmt.Sprintf("(%v, %v, @%v)", node.NodeType, node.ID, node.Lang)
+	}
 	return fmt.Sprintf("(%v, %v)", node.NodeType, node.ID)
 }
 
+// BlankNodeGetter generates the labels of the blank nodes.
+// Get is safe to be called by more than one goroutine.
 type BlankNodeGetter struct {
-	lastid int
+	lastid int64
 }
 
 func (getter *BlankNodeGetter) Get() Node {
-	getter.lastid += 1
+	id := atomic.AddInt64(&getter.lastid, 1)
 	return Node{
 		NodeType: BLANK,
-		ID:       fmt.Sprintf("N%v", getter.lastid),
+		ID:       fmt.Sprintf("N%v", id),
 	}
 }
 
+// GetFromId returns the blank node labelled by rdf:nodeID="id".
 func (getter *BlankNodeGetter) GetFromId(id string) Node {
 	return Node{
-		NodeType: NODEIDLITERAL,
-		ID:       fmt.Sprintf("N%v", id),
+		NodeType: BLANK,
+		ID:       NodeIDPrefix + id,
+	}
+}
+
+// NodeID returns the value of the rdf:nodeID attribute labelling the blank
+// node. ok is false for generated blank nodes and for the other nodes.
+func (node *Node) NodeID() (id string, ok bool) {
+	if node.NodeType != BLANK || !strings.HasPrefix(node.ID, NodeIDPrefix) {
+		return "", false
 	}
+	return strings.TrimPrefix(node.ID, NodeIDPrefix), true
 }
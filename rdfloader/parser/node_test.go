@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"sync"
 	"testing"
 )
 
@@ -33,6 +34,34 @@ func TestBlankNodeGetter_Get(t *testing.T) {
 	}
 }
 
+func TestBlankNodeGetter_Get_concurrent(t *testing.T) {
+	// blank nodes generated by many goroutines at once must be unique.
+	getter := BlankNodeGetter{}
+	nodes := make(chan Node, 1000)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				nodes <- getter.Get()
+			}
+		}()
+	}
+	wg.Wait()
+	close(nodes)
+	ids := map[string]bool{}
+	for node := range nodes {
+		if ids[node.ID] {
+			t.Errorf("blank node %v is generated more than once", node.ID)
+		}
+		ids[node.ID] = true
+	}
+	if len(ids) != 1000 {
+		t.Errorf("expected 1000 blank nodes, found %v", len(ids))
+	}
+}
+
 func TestBlankNodeGetter_GetFromId(t *testing.T) {
 	// default blank node getter:
 	getter := BlankNodeGetter{} // id starts with 1
@@ -42,19 +71,65 @@ func TestBlankNodeGetter_GetFromId(t *testing.T) {
 		t.Errorf("expected first node's id to be N1, found %v", blankNode.ID)
 	}
 
+	// labels of the document never collide with the generated labels.
 	blankNodeA0 := getter.GetFromId("A0")
-	if blankNodeA0.ID != "NA0" {
-		t.Errorf("expected first node's id to be NA0, found %v", blankNodeA0.ID)
+	if blankNodeA0.ID != "_:A0" {
+		t.Errorf("expected first node's id to be _:A0, found %v", blankNodeA0.ID)
+	}
+	if blankNode1 := getter.GetFromId("1"); blankNode1.ID == getter.Get().ID || blankNode1.NodeType != BLANK {
+		t.Errorf("expected a blank node different from the generated blank nodes, found %v", blankNode1)
+	}
+}
+
+func TestNode_NodeID(t *testing.T) {
+	getter := BlankNodeGetter{}
+	// TestCase 1: blank node labelled by the document.
+	node := getter.GetFromId("SPDXRef-1")
+	if id, ok := node.NodeID(); !ok || id != "SPDXRef-1" {
+		t.Errorf("expected nodeID SPDXRef-1, found %v, %v", id, ok)
+	}
+	// TestCase 2: generated blank nodes and other nodes don't have a nodeID.
+	for _, node := range []Node{getter.Get(), {NodeType: LITERAL, ID: "_:literal"}} {
+		if id, ok := node.NodeID(); ok {
+			t.Errorf("expected %v to have no nodeID, found %v", node, id)
+		}
 	}
 }
 
 func TestNode_String(t *testing.T) {
 	// a node is wrapped in a tuple with (NodeType, ID)
 	node := Node{
-		BLANK,
-		"",
+		NodeType: BLANK,
+		ID:       "",
 	}
 	if node.String() != "(BNODE, )" {
 		t.Errorf("String representation of blank node with empty id should be (BNODE, ). Found %v", node.String())
 	}
 }
+
+func TestNode_String_typedLiteral(t *testing.T) {
+	// typed literals carry the datatype in the string representation.
+	// this ensures that "1" and "1"^^xsd:integer are different nodes.
+	node := Node{
+		NodeType: LITERAL,
+		ID:       "1",
+		DataType: "http://www.w3.org/2001/XMLSchema#integer",
+	}
+	expected := "(LITERAL, 1, http://www.w3.org/2001/XMLSchema#integer)"
+	if node.String() != expected {
+		t.Errorf("expected %v, found %v", expected, node.String())
+	}
+}
+
+func TestNode_String_langLiteral(t *testing.T) {
+	// language of a literal is prefixed with @ in the string representation.
+	node := Node{
+		NodeType: LITERAL,
+		ID:       "license",
+		Lang:     "en",
+	}
+	expected := "(LITERAL, license, @en)"
+	if node.String() != expected {
+		t.Errorf("expected %v, found %v", expected, node.String())
+	}
+}
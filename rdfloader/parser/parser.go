@@ -2,13 +2,19 @@ package parser
 
 import (
 	"fmt"
+	"github.com/spdx/gordf/namespace"
+	"github.com/spdx/gordf/namespace/rdf"
 	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
 	"github.com/spdx/gordf/uri"
 	"strings"
 	"sync"
 )
 
-const RDFNS = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+// namespace of the rdf vocabulary.
+const RDFNS = rdf.NS
+
+// namespace bound to the xml prefix by definition. It needn't be declared.
+const XMLNS = "http://www.w3.org/XML/1998/namespace"
 
 type Parser struct {
 	setTriples       map[string]*Triple
@@ -16,12 +22,60 @@ type Parser struct {
 	Triples          []*Triple
 	writeLock        sync.RWMutex
 	nodesWriteLock   sync.RWMutex
+	schemaWriteLock  sync.Mutex
 	SchemaDefinition map[string]uri.URIRef
-	blankNodeGetter  BlankNodeGetter
-	rdfNS            uri.URIRef
-	wg               sync.WaitGroup
+	// same prefixes as the SchemaDefinition. Can be given to the rdfwriter
+	// for writing the triples using the prefixes of the document.
+	Namespaces      *namespace.Manager
+	blankNodeGetter BlankNodeGetter
+	rdfNS           uri.URIRef
+	// blank nodes generated while parsing are labelled again in the order
+	// they are in the document. Maps the label given while parsing to the
+	// node having the label given by the documentBlankNodes.
+	blankNodeLabels    map[string]*Node
+	documentBlankNodes BlankNodeGetter
+	// same as blankNodeLabels for the blank nodes labelled by rdf:nodeID.
+	// used only if they are relabelled or skolemized.
+	nodeIDLabels map[string]*Node
+	// blank nodes labelled by rdf:nodeID keep the labels of the document
+	// by default. if true, they are labelled like the generated blank nodes.
+	// It is useful when the triples of different documents are merged.
+	RelabelBlankNodes bool
+	// if not empty, every blank node is replaced by an IRI made of
+	// SkolemBase and a label generated in the order of the document. Like,
+	//   http://example.com/.well-known/genid/N0
+	// IRIs are IRI nodes as subjects and RESOURCE nodes as objects.
+	SkolemBase string
+	// number of goroutines parsing the node tags concurrently.
+	// runtime.NumCPU() goroutines are used if it is not positive.
+	Workers int
+	// uri of the document. relative uris are resolved against it when
+	// no xml:base is in scope. It must be an absolute uri if given.
+	BaseURI string
+	// literals are kept as they are in the document by default.
+	// if true, leading and trailing whitespaces of the plain and typed
+	// literals given by the content of the property tags are removed.
+	NormalizeLiterals bool
+	// IRIs are kept as they are in the document by default.
+	// if true, IRIs of the nodes, predicates and datatypes are normalized
+	// by uri.Normalize as they are created. IRIs differing only in the case
+	// of the scheme or the host, in percent-encoding or in dot segments
+	// become the same node.
+	NormalizeIRIs bool
+	// if true, ParseEach gives a triple to the handler only once even if the
+	// document has it more than once. It needs the hashes of all the triples.
+	DedupStreamedTriples bool
+	// handler of the triples given to ParseEach. triples are not stored in
+	// the parser when the handler is set.
+	tripleHandler TripleHandler
+	// first error returned by the tripleHandler.
+	handlerErr error
 }
 
+// TripleHandler is given every triple of a document parsed by ParseEach.
+// It is never called concurrently.
+type TripleHandler func(triple *Triple) error
+
 func parseHeaderBlock(rootBlock xmlreader.Block) (map[string]uri.URIRef, error) {
 	// returns all the schema definitions in the root block.
 	// a schema definition is of the form xmlns:SchemaName="URI",
@@ -37,7 +91,7 @@ func parseHeaderBlock(rootBlock xmlreader.Block) (map[string]uri.URIRef, error)
 			if err != nil {
 				return namespaceURI, fmt.Errorf("schema URI %v doesn't confirm to URL rules", attr.Value)
 			}
-			if strings.TrimSuffix(uriref.String(), "#") == strings.TrimSuffix(RDFNS, "#") {
+			if strings.TrimSuffix(uriref.String(), "#") == strings.TrimSuffix(rdf.NS, "#") {
 				anyRDFURI = true
 			}
 			namespaceURI[attr.Name] = uriref
@@ -53,23 +107,29 @@ func parseHeaderBlock(rootBlock xmlreader.Block) (map[string]uri.URIRef, error)
 	// rdfAbbrevPresent: true if user has mapped "rdf" to another uri
 	_, rdfAbbrevPresent := namespaceURI["rdf"]
 	if !anyRDFURI && !rdfAbbrevPresent {
-		rdfURI, _ := uri.NewURIRef(RDFNS)
+		rdfURI, _ := uri.NewURIRef(rdf.NS)
 		namespaceURI["rdf"] = rdfURI
 	}
 	return namespaceURI, nil
 }
 
-func (parser *Parser) getRDFAttributeIndex(tag xmlreader.Tag, attrName string) (index int, err error) {
+func (parser *Parser) getRDFAttributeIndex(tag xmlreader.Tag, tagScope scope, attrName string) (index int, err error) {
 	/*
 		From all the attribute of the given tag, return the index of the attribute rdf:attrName
+		prefixes of the attributes are resolved using the namespaces of the tagScope.
 	*/
 	index = -1
+	rdfAttrURI, err := parser.rdfNS.AddFragment(attrName)
+	if err != nil {
+		return index, err
+	}
 	for i, attr := range tag.Attrs {
-		attrUri, err := parser.uriFromPair(attr.SchemaName, attr.Name)
+		attrUri, err := tagScope.uriFromPair(attr.SchemaName, attr.Name)
 		if err != nil {
-			break
+			// attribute with an undefined prefix can't be a rdf attribute.
+			continue
 		}
-		if attrUri == parser.rdfNS.AddFragment(attrName) {
+		if attrUri.Equal(rdfAttrURI) {
 			// current attribute is a rdf:attrName tag,
 			index = i
 			break
@@ -87,23 +147,151 @@ func getLastURI(tag xmlreader.Tag, lastURI string) string {
 	return lastURI
 }
 
+func getLang(tag xmlreader.Tag, lang string) string {
+	// returns the language in scope of the tag.
+	// xml:lang of the tag overrides the language inherited from the parent.
+	// an empty xml:lang removes the inherited language.
+	for _, attr := range tag.Attrs {
+		if attr.SchemaName == "xml" && attr.Name == "lang" {
+			// language tags are case-insensitive.
+			return strings.ToLower(attr.Value)
+		}
+	}
+	return lang
+}
+
+// values inherited by a tag from the tags enclosing it.
+type scope struct {
+	lastURI string // default namespace given by xmlns attribute
+	lang    string // language given by xml:lang attribute
+	base    string // base uri given by xml:base attribute or the document uri
+	// prefixes declared by the xmlns:prefix attributes of the tag and its
+	// ancestors. Default namespace is mapped by an empty prefix.
+	// the map is shared by the scopes and must never be modified.
+	namespaces map[string]uri.URIRef
+	// true if the IRIs created in the scope are normalized.
+	normalizeIRIs bool
+}
+
+func (parser *Parser) scopeOf(tag xmlreader.Tag, parent scope) (s scope, err error) {
+	// returns the scope of the tag enclosed by the parent scope.
+	s = scope{
+		lastURI:       getLastURI(tag, parent.lastURI),
+		lang:          getLang(tag, parent.lang),
+		base:          parent.base,
+		namespaces:    parent.namespaces,
+		normalizeIRIs: parent.normalizeIRIs,
+	}
+	copied := false
+	for _, attr := range tag.Attrs {
+		if attr.SchemaName == "xmlns" || (attr.SchemaName == "" && attr.Name == "xmlns") {
+			prefix := attr.Name
+			if attr.SchemaName == "" {
+				prefix = ""
+			}
+			if !copied {
+				// copy on write. the parent map is in use by the other blocks.
+				s.namespaces = make(map[string]uri.URIRef, len(parent.namespaces)+1)
+				for key, value := range parent.namespaces {
+					s.namespaces[key] = value
+				}
+				copied = true
+			}
+			if attr.Value == "" && prefix == "" {
+				// xmlns="" removes the default namespace.
+				delete(s.namespaces, prefix)
+				continue
+			}
+			uriref, err := uri.NewURIRef(attr.Value)
+			if err != nil {
+				return s, fmt.Errorf("schema URI %v doesn't confirm to URL rules", attr.Value)
+			}
+			s.namespaces[prefix] = uriref
+			if prefix != "" {
+				parser.addSchemaDefinition(prefix, uriref)
+			}
+		}
+		if attr.SchemaName == "xml" && attr.Name == "base" {
+			// xml:base can be relative to the base uri of the parent.
+			if parent.base == "" {
+				s.base, err = uri.Resolve(attr.Value, "")
+			} else {
+				s.base, err = uri.Resolve(parent.base, attr.Value)
+			}
+			if err != nil {
+				return s, fmt.Errorf("invalid xml:base %v: %v", attr.Value, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s scope) resolve(reference string) (string, error) {
+	// returns the absolute uri of the reference given in the scope.
+	// uri references in rdf:about, rdf:resource, rdf:ID and rdf:datatype
+	// attributes are resolved using the base uri of the scope.
+	resolved, err := s.resolveReference(reference)
+	if err != nil || !s.normalizeIRIs {
+		return resolved, err
+	}
+	return uri.Normalize(resolved), nil
+}
+
+func (s scope) resolveReference(reference string) (string, error) {
+	if s.base != "" {
+		return uri.Resolve(s.base, reference)
+	}
+	if strings.HasPrefix(reference, "#") {
+		// without any base uri, fragments are resolved using the default namespace.
+		baseURI, err := uri.NewURIRef(s.lastURI)
+		if err != nil {
+			return "", err
+		}
+		resolvedURI, err := baseURI.AddFragment(reference)
+		if err != nil {
+			return "", err
+		}
+		return resolvedURI.String(), nil
+	}
+	return reference, nil
+}
+
 func New() (parser *Parser) {
 	// creates a new parser object
-	rdfNS, _ := uri.NewURIRef(RDFNS)
+	rdfNS, _ := uri.NewURIRef(rdf.NS)
 	return &Parser{
-		setTriples:       map[string]*Triple{},
-		setNodes:         map[string]*Node{},
-		Triples:          []*Triple{},
-		writeLock:        sync.RWMutex{},
-		nodesWriteLock:   sync.RWMutex{},
-		SchemaDefinition: map[string]uri.URIRef{"": uri.URIRef{}},
-		blankNodeGetter:  BlankNodeGetter{-1},
-		wg:               sync.WaitGroup{},
-		rdfNS:            rdfNS,
+		setTriples:         map[string]*Triple{},
+		setNodes:           map[string]*Node{},
+		Triples:            []*Triple{},
+		writeLock:          sync.RWMutex{},
+		nodesWriteLock:     sync.RWMutex{},
+		SchemaDefinition:   map[string]uri.URIRef{"": uri.URIRef{}},
+		Namespaces:         namespace.NewManager(),
+		blankNodeGetter:    BlankNodeGetter{-1},
+		blankNodeLabels:    map[string]*Node{},
+		documentBlankNodes: BlankNodeGetter{-1},
+		nodeIDLabels:       map[string]*Node{},
+		rdfNS:              rdfNS,
+	}
+}
+
+func (parser *Parser) addSchemaDefinition(prefix string, uriref uri.URIRef) {
+	// adds a prefix declared by a nested tag to the schema definitions of
+	// the parser if neither the prefix nor the uri is already in use.
+	// declarations of the root tag always take precedence.
+	parser.schemaWriteLock.Lock()
+	defer parser.schemaWriteLock.Unlock()
+	if _, exists := parser.Namespaces.Namespace(prefix); exists {
+		return
 	}
+	if _, exists := parser.Namespaces.Prefix(uriref); exists {
+		return
+	}
+	parser.Namespaces.Bind(prefix, uriref)
+	parser.SchemaDefinition[prefix] = uriref
 }
 
-func (parser *Parser) parseBlock(currBlock *xmlreader.Block, node *Node, lastURI string, errp *error) {
+func (parser *Parser) parseBlock(task *blockTask, pool *workerPool) (err error) {
 	/*
 		1. What is a block?
 		Ans: A rdf block is made up of
@@ -122,56 +310,178 @@ func (parser *Parser) parseBlock(currBlock *xmlreader.Block, node *Node, lastURI
 				Predicate: Apacha-2.0							(Literal)
 			If the rdf:about attribute of the subject is removed, it will become a blank node.
 
-		3. What is a node *Node?
-		Ans: effectively, node representation of the block parameter.
+		3. What is a task.node *Node?
+		Ans: effectively, node representation of the task.block.
 			 node := parser.nodeFromTag(block)
 
-		4. Parameter errp.
-			Pointer to an error variable.
-			used to report errors in a concurrent environment.
-			why pointer? Because go func() cannot return anything.
+		4. What is a task.scope?
+			default namespace, language and base uri given by the ancestors.
+			language is attached to the plain literals of the block and
+			base uri is used to resolve the relative uris of the block.
+
+		5. Parameter pool.
+			workers parsing the node blocks concurrently.
+			node blocks nested in the block are submitted to the pool.
+			triples of the block are added to the task in document order.
+
+		6. Errors.
+			errors are returned as a xmlreader.ParseError at the position
+			of the tag having the error.
 	*/
-	node = parser.resolveNode(node)
-	defer parser.wg.Done()
-	lastURI = getLastURI(currBlock.OpeningTag, lastURI)
-	if len(currBlock.Children) == 0 {
-		// adding only one triple which identifies the type of the current block.
-		predicateURI := parser.rdfNS.AddFragment("type")
-		openingTagUri, newErr := parser.uriFromPair(currBlock.OpeningTag.SchemaName, currBlock.OpeningTag.Name)
-		if newErr != nil {
-			*errp = newErr
-			return
-		}
-		parser.appendTriple(&Triple{
+	currBlock := task.block
+	defer func() {
+		err = tagError(currBlock.OpeningTag, err)
+	}()
+	node := parser.resolveNode(task.node)
+	currScope, newErr := parser.scopeOf(currBlock.OpeningTag, task.scope)
+	if newErr != nil {
+		return newErr
+	}
+
+	// adding the triple which identifies the type of the current block.
+	// (node) -> rdf:type -> (openingTagURI)
+	// rdf:Description tags describe untyped nodes.
+	openingTagUri, newErr := currScope.uriFromPair(currBlock.OpeningTag.SchemaName, currBlock.OpeningTag.Name)
+	if newErr != nil {
+		return newErr
+	}
+	if openingTagUri.String() != rdf.Description.String() {
+		task.addTriple(&Triple{
 			Subject:   node,
-			Predicate: &Node{IRI, predicateURI.String()},
-			Object:    &Node{IRI, openingTagUri.String()},
+			Predicate: &Node{NodeType: IRI, ID: rdf.Type.String()},
+			Object:    &Node{NodeType: IRI, ID: openingTagUri.String()},
 		})
-		return
 	}
-	for _, predicateBlock := range currBlock.Children {
+
+	// properties given as the attributes of the node tag.
+	attrPredicates, attrObjects, newErr := parser.getPropertyAttributes(currBlock.OpeningTag, currScope)
+	if newErr != nil {
+		return newErr
+	}
+	for i := range attrPredicates {
+		task.addTriple(&Triple{
+			Subject:   node,
+			Predicate: attrPredicates[i],
+			Object:    attrObjects[i],
+		})
+	}
+	return parser.parsePropertyBlocks(currBlock.Children, node, currScope, task, pool)
+}
+
+func (parser *Parser) parsePropertyBlocks(propertyBlocks []*xmlreader.Block, node *Node, nodeScope scope, task *blockTask, pool *workerPool) (err error) {
+	// parses the children of a node block.
+	// every child is a predicate block describing a property of the node.
+	// nodeScope is the scope of the parent node block and task is the task
+	// of the node block.
+
+	// rdf:li tags are numbered as rdf:_1, rdf:_2, ... in the order they
+	// appear in the node block. The counter is local to the node block
+	// because the property blocks of a node block are never parsed by more
+	// than one goroutine.
+	liCounter := 0
+	// errors are reported at the position of the predicate block being parsed.
+	var predicateBlock *xmlreader.Block
+	defer func() {
+		if predicateBlock != nil {
+			err = tagError(predicateBlock.OpeningTag, err)
+		}
+	}()
+	for _, predicateBlock = range propertyBlocks {
+		predicateScope, newErr := parser.scopeOf(predicateBlock.OpeningTag, nodeScope)
+		if newErr != nil {
+			return newErr
+		}
 		// predicateURI can't be a blank node. It has to be a URI Reference
 		//     according to https://www.w3.org/TR/rdf-concepts/#dfn-predicate
-		predicateURI, newErr := parser.uriFromPair(predicateBlock.OpeningTag.SchemaName, predicateBlock.OpeningTag.Name)
+		predicateURI, newErr := predicateScope.uriFromPair(predicateBlock.OpeningTag.SchemaName, predicateBlock.OpeningTag.Name)
 		if newErr != nil {
-			*errp = fmt.Errorf("error creating a reference URI link for the predicate block. %v", newErr)
-			return
+			return fmt.Errorf("error creating a reference URI link for the predicate block. %v", newErr)
 		}
 		predicateNode := &Node{NodeType: IRI, ID: predicateURI.String()}
+		if predicateNode.ID == rdf.Li.String() {
+			liCounter++
+			predicateNode.ID = rdf.Member(liCounter).String()
+		}
+		// rdf:ID of a property tag names the statement reifying the triple
+		// generated by the property tag.
+		statementNode, newErr := parser.statementFromTag(predicateBlock.OpeningTag, predicateScope)
+		if newErr != nil {
+			return newErr
+		}
 
-		openingTagUri, newErr := parser.uriFromPair(currBlock.OpeningTag.SchemaName, currBlock.OpeningTag.Name)
+		parseTypeIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, predicateScope, "parseType")
 		if newErr != nil {
-			*errp = newErr
-			return
+			return newErr
+		}
+		parseType := ""
+		if parseTypeIdx != -1 {
+			parseType = predicateBlock.OpeningTag.Attrs[parseTypeIdx].Value
+		}
+		switch {
+		case parseTypeIdx == -1:
+			// no rdf:parseType attribute.
+		case parseType == "Resource":
+			// rdf:parseType="Resource" is an abbreviation of a blank node.
+			// the object is a new blank node and
+			// the children of the predicate block are the properties of the blank node.
+			//   <spdx:checksum rdf:parseType="Resource">
+			//       <spdx:algorithm>SHA1</spdx:algorithm>
+			//   </spdx:checksum>
+			// is same as:
+			//   <spdx:checksum>
+			//       <rdf:Description>
+			//           <spdx:algorithm>SHA1</spdx:algorithm>
+			//       </rdf:Description>
+			//   </spdx:checksum>
+			// except that no rdf:type triple is generated for the blank node.
+			blankNode := parser.blankNodeGetter.Get()
+			objectNode := parser.resolveNode(&blankNode)
+			task.addStatement(&Triple{
+				Subject:   node,
+				Predicate: predicateNode,
+				Object:    objectNode,
+			}, statementNode)
+			newErr = parser.parsePropertyBlocks(predicateBlock.Children, objectNode, predicateScope, task, pool)
+			if newErr != nil {
+				return newErr
+			}
+			continue
+		case parseType == "Collection":
+			// rdf:parseType="Collection" is an abbreviation of a rdf:List.
+			// the object is the head of a chain of blank nodes linked by
+			// rdf:rest. rdf:first of every blank node is one of the children.
+			//   <owl:unionOf rdf:parseType="Collection">
+			//       <owl:Class rdf:about="#A"/>
+			//       <owl:Class rdf:about="#B"/>
+			//   </owl:unionOf>
+			// generates:
+			//   (node) -> owl:unionOf -> (N1)
+			//   (N1) -> rdf:first -> (#A)
+			//   (N1) -> rdf:rest  -> (N2)
+			//   (N2) -> rdf:first -> (#B)
+			//   (N2) -> rdf:rest  -> (rdf:nil)
+			newErr = parser.parseCollection(predicateBlock.Children, node, predicateNode, statementNode, predicateScope, task, pool)
+			if newErr != nil {
+				return newErr
+			}
+			continue
+		default:
+			// rdf:parseType="Literal".
+			// any other value of rdf:parseType is treated as "Literal" too.
+			// the xmlreader reads the content of such blocks as it is into the
+			// value of the block. The object is a literal of rdf:XMLLiteral type.
+			task.addStatement(&Triple{
+				Subject:   node,
+				Predicate: predicateNode,
+				Object: &Node{
+					NodeType: LITERAL,
+					ID:       predicateBlock.Value,
+					DataType: rdf.XMLLiteral.String(),
+				},
+			}, statementNode)
+			continue
 		}
 
-		// (node) -> rdf:type -> (openingTagURI)
-		predicateURI = parser.rdfNS.AddFragment("type")
-		parser.appendTriple(&Triple{
-			Subject:   node,
-			Predicate: &Node{IRI, predicateURI.String()},
-			Object:    &Node{IRI, openingTagUri.String()},
-		})
 		if len(predicateBlock.Children) == 0 {
 			// no children.
 			currentTriple := &Triple{
@@ -179,88 +489,283 @@ func (parser *Parser) parseBlock(currBlock *xmlreader.Block, node *Node, lastURI
 				Predicate: predicateNode,
 				Object:    nil,
 			}
-			resIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, "resource")
-			*errp = newErr
-			if *errp != nil {
-				return
+			resIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, predicateScope, "resource")
+			if newErr != nil {
+				return newErr
 			}
-			nodeidIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, "nodeID")
-			*errp = newErr
-			if *errp != nil {
-				return
+			nodeidIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, predicateScope, "nodeID")
+			if newErr != nil {
+				return newErr
+			}
+			attrPredicates, attrObjects, newErr := parser.getPropertyAttributes(predicateBlock.OpeningTag, predicateScope)
+			if newErr != nil {
+				return newErr
 			}
+			// subject of the triples of the property attributes.
+			var attrSubject *Node
 
 			switch {
 			case resIdx != -1:
 				// rdf:resource attribute is present
+				resource, newErr := predicateScope.resolve(predicateBlock.OpeningTag.Attrs[resIdx].Value)
+				if newErr != nil {
+					return newErr
+				}
 				currentTriple.Object = &Node{
 					NodeType: RESOURCELITERAL,
-					ID:       predicateBlock.OpeningTag.Attrs[resIdx].Value,
+					ID:       resource,
 				}
+				attrSubject = &Node{NodeType: IRI, ID: currentTriple.Object.ID}
 			case nodeidIdx != -1:
 				// we have a reference to another block via rdf:nodeID
-				currentTriple.Object = &Node{
-					NodeType: NODEIDLITERAL,
-					ID:       parser.blankNodeGetter.GetFromId(predicateBlock.OpeningTag.Attrs[nodeidIdx].Value).ID,
-				}
+				blankNode := parser.blankNodeGetter.GetFromId(predicateBlock.OpeningTag.Attrs[nodeidIdx].Value)
+				currentTriple.Object = parser.resolveNode(&blankNode)
+				attrSubject = currentTriple.Object
+			case len(attrPredicates) > 0:
+				// property attributes without rdf:resource or rdf:nodeID
+				// describes a new blank node.
+				//   <spdx:checksum spdx:algorithm="SHA1"/>
+				// is same as:
+				//   <spdx:checksum rdf:parseType="Resource">
+				//       <spdx:algorithm>SHA1</spdx:algorithm>
+				//   </spdx:checksum>
+				blankNode := parser.blankNodeGetter.Get()
+				currentTriple.Object = parser.resolveNode(&blankNode)
+				attrSubject = currentTriple.Object
 			default:
 				// it is a literal node without any special attributes
-				resIdx, newErr = parser.getRDFAttributeIndex(predicateBlock.OpeningTag, "nodeID")
 				currentTriple.Object = &Node{
 					NodeType: LITERAL,
 					ID:       predicateBlock.Value,
 				}
+				if parser.NormalizeLiterals {
+					currentTriple.Object.ID = strings.Trim(currentTriple.Object.ID, " \t\r\n")
+				}
+				datatypeIdx, newErr := parser.getRDFAttributeIndex(predicateBlock.OpeningTag, predicateScope, "datatype")
+				if newErr != nil {
+					return newErr
+				}
+				if datatypeIdx != -1 {
+					// literal is a typed literal. rdf:datatype attribute holds the datatype IRI.
+					currentTriple.Object.DataType, newErr = predicateScope.resolve(predicateBlock.OpeningTag.Attrs[datatypeIdx].Value)
+					if newErr != nil {
+						return newErr
+					}
+				} else {
+					// typed literals doesn't have a language.
+					currentTriple.Object.Lang = predicateScope.lang
+				}
 			}
 
 			// registering a new Triple:
-			parser.appendTriple(currentTriple)
+			task.addStatement(currentTriple, statementNode)
+			for i := range attrPredicates {
+				task.addTriple(&Triple{
+					Subject:   attrSubject,
+					Predicate: attrPredicates[i],
+					Object:    attrObjects[i],
+				})
+			}
 		}
 
 		// the predicate block has children
 		for _, objectBlock := range predicateBlock.Children {
-			objectNode, newErr := parser.nodeFromTag(objectBlock.OpeningTag, lastURI)
+			objectNode, newErr := parser.nodeFromTag(objectBlock.OpeningTag, predicateScope)
 			if newErr != nil {
-				*errp = newErr
-				return
+				return newErr
 			}
 
-			parser.appendTriple(&Triple{
+			task.addStatement(&Triple{
 				Subject:   node,
 				Predicate: predicateNode,
 				Object:    objectNode,
-			})
-			parser.wg.Add(1)
-			go parser.parseBlock(objectBlock, objectNode, lastURI, errp)
-			if *errp != nil {
-				return
-			}
+			}, statementNode)
+			// object block is parsed by another worker.
+			nestedTask := &blockTask{block: objectBlock, node: objectNode, scope: predicateScope}
+			task.addNested(nestedTask)
+			pool.submit(nestedTask)
 		}
 	}
+	return nil
+}
+
+func (parser *Parser) parseCollection(itemBlocks []*xmlreader.Block, node, predicateNode, statementNode *Node, predicateScope scope, task *blockTask, pool *workerPool) error {
+	// creates the rdf:first/rdf:rest list of the item blocks and links it
+	// to the node using the predicateNode.
+	// the link is reified by the statementNode if it is not nil.
+	// predicateScope is the scope of the tag having rdf:parseType="Collection".
+	// every item block is a node block which is parsed concurrently.
+	rdfFirst := &Node{NodeType: IRI, ID: rdf.First.String()}
+	rdfRest := &Node{NodeType: IRI, ID: rdf.Rest.String()}
+	rdfNil := parser.resolveNode(&Node{NodeType: IRI, ID: rdf.Nil.String()})
+
+	itemNodes := make([]*Node, len(itemBlocks))
+	listNodes := make([]*Node, len(itemBlocks)+1)
+	for i, itemBlock := range itemBlocks {
+		itemNode, err := parser.nodeFromTag(itemBlock.OpeningTag, predicateScope)
+		if err != nil {
+			return err
+		}
+		itemNodes[i] = itemNode
+		blankNode := parser.blankNodeGetter.Get()
+		listNodes[i] = parser.resolveNode(&blankNode)
+	}
+	// list ends with rdf:nil. An empty collection is same as rdf:nil.
+	listNodes[len(itemBlocks)] = rdfNil
+
+	task.addStatement(&Triple{
+		Subject:   node,
+		Predicate: predicateNode,
+		Object:    listNodes[0],
+	}, statementNode)
+	for i, itemBlock := range itemBlocks {
+		task.addTriple(&Triple{
+			Subject:   listNodes[i],
+			Predicate: rdfFirst,
+			Object:    itemNodes[i],
+		})
+		task.addTriple(&Triple{
+			Subject:   listNodes[i],
+			Predicate: rdfRest,
+			Object:    listNodes[i+1],
+		})
+		nestedTask := &blockTask{block: itemBlock, node: itemNodes[i], scope: predicateScope}
+		task.addNested(nestedTask)
+		pool.submit(nestedTask)
+	}
+	return nil
 }
 
+// Parse parses the tree of blocks read from a document. Errors in the
+// document are returned as a *xmlreader.ParseError.
 func (parser *Parser) Parse(rootBlock xmlreader.Block) (err error) {
-	// set all the schema definitions in the root block.
-	schemaDefinition, err := parseHeaderBlock(rootBlock)
+	documentScope, rootScope, isRDF, err := parser.parseRootTag(rootBlock.OpeningTag)
 	if err != nil {
 		return err
 	}
-	parser.SchemaDefinition = schemaDefinition
+	if !isRDF {
+		// rdf:RDF tag can be omitted if the document has only one node tag.
+		// in that case, root tag itself is the node tag.
+		//   <spdx:SpdxDocument xmlns:spdx="..." rdf:about="...">
+		//       ...
+		//   </spdx:SpdxDocument>
+		return parser.parseNodeBlocks([]*xmlreader.Block{&rootBlock}, documentScope)
+	}
+	// children of the rdf:RDF tag are the node tags.
+	return parser.parseNodeBlocks(rootBlock.Children, rootScope)
+}
 
-	// root tag is set now.
-	var childNode *Node
-	xmlns := schemaDefinition[""]
-	xmlnsString := xmlns.String()
-	for _, child := range rootBlock.Children {
-		childNode, err = parser.nodeFromTag(child.OpeningTag, xmlnsString)
+// ParseEach parses the document read by the reader without holding all of it
+// in memory. Every triple is given to the handler as soon as the node tag
+// containing it is parsed. Triples are not stored in the parser.
+// Triples are deduplicated only if DedupStreamedTriples is true.
+// Returns the first error returned by the handler, if any. Errors in the
+// document are returned as a *xmlreader.ParseError.
+func (parser *Parser) ParseEach(reader xmlreader.Reader, handler TripleHandler) (err error) {
+	parser.tripleHandler = handler
+	defer func() {
+		parser.tripleHandler = nil
+	}()
+
+	var documentScope, rootScope scope
+	var rootBlock xmlreader.Block
+	isRDF := false
+	err = reader.ReadEach(func(root xmlreader.Block) (err error) {
+		rootBlock = root
+		documentScope, rootScope, isRDF, err = parser.parseRootTag(root.OpeningTag)
+		return err
+	}, func(child xmlreader.Block) error {
+		if !isRDF {
+			// root tag itself is the only node tag. It is parsed at the end.
+			rootBlock.Children = append(rootBlock.Children, &child)
+			return nil
+		}
+		err := parser.parseNodeBlocks([]*xmlreader.Block{&child}, rootScope)
 		if err != nil {
 			return err
 		}
-		parser.wg.Add(1)
-		go parser.parseBlock(child, childNode, xmlnsString, &err)
+		// nodes of a node tag are not needed for parsing the next node tag.
+		parser.nodesWriteLock.Lock()
+		parser.setNodes = map[string]*Node{}
+		parser.nodesWriteLock.Unlock()
+		parser.blankNodeLabels = map[string]*Node{}
+		return parser.handlerErr
+	})
+	if err != nil {
+		return err
+	}
+	if !isRDF {
+		err = parser.parseNodeBlocks([]*xmlreader.Block{&rootBlock}, documentScope)
 		if err != nil {
 			return err
 		}
 	}
-	parser.wg.Wait() // wait for all the go routines to finish executing.
-	return err
+	return parser.handlerErr
+}
+
+// sets the schema definitions declared by the root tag. Returns the scope
+// of the document, the scope of the root tag and whether the root tag is
+// a rdf:RDF tag.
+func (parser *Parser) parseRootTag(rootTag xmlreader.Tag) (documentScope, rootScope scope, isRDF bool, err error) {
+	defer func() {
+		err = tagError(rootTag, err)
+	}()
+	// set all the schema definitions in the root block.
+	schemaDefinition, err := parseHeaderBlock(xmlreader.Block{OpeningTag: rootTag})
+	if err != nil {
+		return documentScope, rootScope, isRDF, err
+	}
+	parser.SchemaDefinition = schemaDefinition
+	parser.Namespaces = namespace.NewManagerFromBindings(schemaDefinition)
+
+	// SchemaDefinition is modified while parsing. Scopes use a copy of it.
+	documentScope = scope{namespaces: map[string]uri.URIRef{}, normalizeIRIs: parser.NormalizeIRIs}
+	for prefix, uriref := range schemaDefinition {
+		documentScope.namespaces[prefix] = uriref
+	}
+	if parser.BaseURI != "" {
+		// base uri of the document without its fragment.
+		documentScope.base, err = uri.Resolve(parser.BaseURI, "")
+		if err != nil {
+			return documentScope, rootScope, isRDF, err
+		}
+	}
+	rootScope, err = parser.scopeOf(rootTag, documentScope)
+	if err != nil {
+		return documentScope, rootScope, isRDF, err
+	}
+	rootURI, err := rootScope.uriFromPair(rootTag.SchemaName, rootTag.Name)
+	if err != nil {
+		return documentScope, rootScope, isRDF, err
+	}
+	return documentScope, rootScope, rootURI.String() == rdf.RDF.String(), nil
+}
+
+// parses the node tags in the given scope.
+// node tags and the node tags nested in them are parsed concurrently by the
+// workers of a pool. Triples are appended in the order of the document once
+// all of them are parsed.
+func (parser *Parser) parseNodeBlocks(nodeBlocks []*xmlreader.Block, nodeScope scope) error {
+	var pool *workerPool
+	pool = newWorkerPool(parser.Workers, func(task *blockTask) error {
+		return parser.parseBlock(task, pool)
+	})
+	tasks := make([]*blockTask, 0, len(nodeBlocks))
+	for _, child := range nodeBlocks {
+		childNode, err := parser.nodeFromTag(child.OpeningTag, nodeScope)
+		if err != nil {
+			pool.fail(err)
+			break
+		}
+		task := &blockTask{block: child, node: childNode, scope: nodeScope}
+		tasks = append(tasks, task)
+		pool.submit(task)
+	}
+	if err := pool.wait(); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		parser.appendTask(task)
+	}
+	return nil
 }
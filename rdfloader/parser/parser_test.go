@@ -3,8 +3,12 @@ package parser
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
 	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
 	"io"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -14,9 +18,9 @@ func xmlreaderFromString(fileContent string) xmlreader.XMLReader {
 
 func TestTriple_Hash(t *testing.T) {
 	testTriple := Triple{
-		Subject:   &Node{BLANK, ""},
-		Predicate: &Node{BLANK, ""},
-		Object:    &Node{BLANK, ""},
+		Subject:   &Node{NodeType: BLANK, ID: ""},
+		Predicate: &Node{NodeType: BLANK, ID: ""},
+		Object:    &Node{NodeType: BLANK, ID: ""},
 	}
 
 	expectedHash := "{(BNODE, ); (BNODE, ); (BNODE, )}"
@@ -125,8 +129,9 @@ func TestParser_Parse(t *testing.T) {
 		if err != nil {
 			t.Errorf("error parsing a valid rdf file. Error: %v", err)
 		}
-		if len(rdfParser.Triples) != 2 {
-			t.Errorf("expected rdfParser to have exactly two triples. %v triples found", len(rdfParser.Triples))
+		// rdf:Description tag doesn't generate a rdf:type triple.
+		if len(rdfParser.Triples) != 1 {
+			t.Errorf("expected rdfParser to have exactly one triple. %v triples found", len(rdfParser.Triples))
 		}
 	}()
 
@@ -170,6 +175,1015 @@ func TestParser_Parse(t *testing.T) {
 			t.Errorf("expected an error stating opening and closing tags are not same")
 		}
 	}()
+
+	// TestCase 7: typed literal must keep the datatype IRI given by rdf:datatype
+	func() {
+		typedLiteralRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:CreationInfo>
+					<spdx:created rdf:datatype="http://www.w3.org/2001/XMLSchema#dateTime">2016-09-28T19:13:38Z</spdx:created>
+				</spdx:CreationInfo>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(typedLiteralRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		var literal *Node
+		for _, triple := range rdfParser.Triples {
+			if triple.Predicate.ID == "http://spdx.org/rdf/terms#created" {
+				literal = triple.Object
+			}
+		}
+		if literal == nil {
+			t.Errorf("expected a triple with spdx:created predicate. Found %v", rdfParser.Triples)
+			return
+		}
+		if literal.NodeType != LITERAL || literal.ID != "2016-09-28T19:13:38Z" {
+			t.Errorf("expected a literal with value 2016-09-28T19:13:38Z, found %v", literal)
+		}
+		if expectedType := "http://www.w3.org/2001/XMLSchema#dateTime"; literal.DataType != expectedType {
+			t.Errorf("expected datatype %v, found %v", expectedType, literal.DataType)
+		}
+	}()
+
+	// TestCase 8: xml:lang is inherited by the children and literals
+	// differing only by language must be different triples.
+	func() {
+		multilingualRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#"
+				xml:lang="en">
+				<rdf:Description rdf:about="http://spdx.org/licenses/MIT">
+					<rdfs:comment>license</rdfs:comment>
+					<rdfs:comment xml:lang="FR">license</rdfs:comment>
+					<rdfs:comment xml:lang="">license</rdfs:comment>
+				</rdf:Description>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(multilingualRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		langs := map[string]bool{}
+		for _, triple := range rdfParser.Triples {
+			if triple.Predicate.ID == "http://www.w3.org/2000/01/rdf-schema#comment" {
+				langs[triple.Object.Lang] = true
+			}
+		}
+		// one triple per language and one without any language.
+		expectedLangs := map[string]bool{"en": true, "fr": true, "": true}
+		if !reflect.DeepEqual(langs, expectedLangs) {
+			t.Errorf("expected comments with languages %v, found %v", expectedLangs, langs)
+		}
+	}()
+
+	// TestCase 9: rdf:parseType="Resource" creates a blank node whose
+	// properties are the children of the predicate block.
+	func() {
+		parseTypeResourceRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+					<spdx:checksum rdf:parseType="Resource">
+						<spdx:algorithm>SHA1</spdx:algorithm>
+						<spdx:checksumValue>d6a770ba38583ed4bb4525bd96e50461655d2758</spdx:checksumValue>
+					</spdx:checksum>
+				</spdx:File>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(parseTypeResourceRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		// expected triples:
+		//   1. File rdf:type spdx:File
+		//   2. File spdx:checksum N
+		//   3. N spdx:algorithm SHA1
+		//   4. N spdx:checksumValue d6a770ba38583ed4bb4525bd96e50461655d2758
+		if len(rdfParser.Triples) != 4 {
+			t.Errorf("expected 4 triples, found %v: %v", len(rdfParser.Triples), rdfParser.Triples)
+		}
+		var checksumNode *Node
+		for _, triple := range rdfParser.Triples {
+			if triple.Predicate.ID == "http://spdx.org/rdf/terms#checksum" {
+				checksumNode = triple.Object
+			}
+		}
+		if checksumNode == nil || checksumNode.NodeType != BLANK {
+			t.Errorf("expected object of spdx:checksum to be a blank node, found %v", checksumNode)
+			return
+		}
+		for _, triple := range rdfParser.Triples {
+			switch triple.Predicate.ID {
+			case RDFNS + "type":
+				if triple.Subject == checksumNode {
+					t.Errorf("blank node of rdf:parseType=\"Resource\" must not have a rdf:type triple")
+				}
+			case "http://spdx.org/rdf/terms#algorithm", "http://spdx.org/rdf/terms#checksumValue":
+				if triple.Subject != checksumNode {
+					t.Errorf("expected subject of %v to be the checksum blank node, found %v", triple.Predicate, triple.Subject)
+				}
+			}
+		}
+	}()
+
+	// TestCase 10: rdf:parseType="Literal" creates a xml literal from the
+	// content of the predicate block.
+	func() {
+		parseTypeLiteralRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#"
+				xmlns:h="http://www.w3.org/1999/xhtml">
+				<spdx:License rdf:about="http://spdx.org/licenses/MIT">
+					<spdx:licenseText rdf:parseType="Literal"><h:p>Permission is <h:b>hereby</h:b> granted</h:p></spdx:licenseText>
+				</spdx:License>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(parseTypeLiteralRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		// expected triples:
+		//   1. License rdf:type spdx:License
+		//   2. License spdx:licenseText "<h:p ...>...</h:p>"^^rdf:XMLLiteral
+		if len(rdfParser.Triples) != 2 {
+			t.Errorf("expected 2 triples, found %v: %v", len(rdfParser.Triples), rdfParser.Triples)
+		}
+		expectedLiteral := &Node{
+			NodeType: LITERAL,
+			ID:       `<h:p xmlns:h="http://www.w3.org/1999/xhtml">Permission is <h:b>hereby</h:b> granted</h:p>`,
+			DataType: RDFNS + "XMLLiteral",
+		}
+		for _, triple := range rdfParser.Triples {
+			if triple.Predicate.ID == "http://spdx.org/rdf/terms#licenseText" && !reflect.DeepEqual(triple.Object, expectedLiteral) {
+				t.Errorf("expected object %v, found %v", expectedLiteral, triple.Object)
+			}
+		}
+	}()
+
+	// TestCase 11: rdf:parseType="Collection" creates a rdf:first/rdf:rest
+	// chain of blank nodes ending in rdf:nil.
+	func() {
+		parseTypeCollectionRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:owl="http://www.w3.org/2002/07/owl#">
+				<owl:Class rdf:about="http://spdx.org/rdf/terms#SpdxItem">
+					<owl:unionOf rdf:parseType="Collection">
+						<owl:Class rdf:about="http://spdx.org/rdf/terms#File"/>
+						<owl:Class rdf:about="http://spdx.org/rdf/terms#Package"/>
+					</owl:unionOf>
+					<owl:disjointWith rdf:parseType="Collection"/>
+				</owl:Class>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(parseTypeCollectionRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		// objects of the triples having the given subject and predicate.
+		objects := func(subject, predicate string) (nodes []*Node) {
+			for _, triple := range rdfParser.Triples {
+				if triple.Subject.ID == subject && triple.Predicate.ID == predicate {
+					nodes = append(nodes, triple.Object)
+				}
+			}
+			return nodes
+		}
+
+		// empty collection is rdf:nil
+		emptyList := objects("http://spdx.org/rdf/terms#SpdxItem", "http://www.w3.org/2002/07/owl#disjointWith")
+		if len(emptyList) != 1 || emptyList[0].ID != RDFNS+"nil" {
+			t.Errorf("expected empty collection to be rdf:nil, found %v", emptyList)
+		}
+
+		// traversing the list.
+		var items []string
+		heads := objects("http://spdx.org/rdf/terms#SpdxItem", "http://www.w3.org/2002/07/owl#unionOf")
+		if len(heads) != 1 {
+			t.Errorf("expected exactly one owl:unionOf triple, found %v", heads)
+			return
+		}
+		for node := heads[0]; node.ID != RDFNS+"nil"; {
+			if node.NodeType != BLANK {
+				t.Errorf("expected list node to be a blank node, found %v", node)
+				return
+			}
+			first, rest := objects(node.ID, RDFNS+"first"), objects(node.ID, RDFNS+"rest")
+			if len(first) != 1 || len(rest) != 1 {
+				t.Errorf("list node %v must have exactly one rdf:first and rdf:rest. found %v and %v", node, first, rest)
+				return
+			}
+			items = append(items, first[0].ID)
+			node = rest[0]
+		}
+		expectedItems := []string{"http://spdx.org/rdf/terms#File", "http://spdx.org/rdf/terms#Package"}
+		if !reflect.DeepEqual(items, expectedItems) {
+			t.Errorf("expected items %v, found %v", expectedItems, items)
+		}
+	}()
+
+	// TestCase 12: property attributes of the node tags and of the empty
+	// property tags are the properties with literal objects.
+	func() {
+		propertyAttributesRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1" spdx:fileName="./src/main file.c" xml:lang="en">
+					<spdx:checksum spdx:algorithm="SHA1" spdx:checksumValue="abc" rdf:type="http://spdx.org/rdf/terms#Checksum"/>
+					<spdx:licenseConcluded rdf:resource="http://spdx.org/licenses/MIT" spdx:licenseId="MIT"/>
+				</spdx:File>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(propertyAttributesRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		// triples as (subject, predicate, object) strings.
+		triples := map[[3]string]bool{}
+		var checksumNode *Node
+		for _, triple := range rdfParser.Triples {
+			triples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] = true
+			if triple.Predicate.ID == "http://spdx.org/rdf/terms#checksum" {
+				checksumNode = triple.Object
+			}
+		}
+		if checksumNode == nil || checksumNode.NodeType != BLANK {
+			t.Errorf("expected object of spdx:checksum to be a new blank node, found %v", checksumNode)
+			return
+		}
+		file := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)"
+		expectedTriples := [][3]string{
+			{file, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#File)"},
+			{file, "http://spdx.org/rdf/terms#fileName", "(LITERAL, ./src/main file.c, @en)"},
+			{file, "http://spdx.org/rdf/terms#checksum", checksumNode.String()},
+			{checksumNode.String(), "http://spdx.org/rdf/terms#algorithm", "(LITERAL, SHA1, @en)"},
+			{checksumNode.String(), "http://spdx.org/rdf/terms#checksumValue", "(LITERAL, abc, @en)"},
+			{checksumNode.String(), RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#Checksum)"},
+			{file, "http://spdx.org/rdf/terms#licenseConcluded", "(RESOURCE, http://spdx.org/licenses/MIT)"},
+			{"(IRI, http://spdx.org/licenses/MIT)", "http://spdx.org/rdf/terms#licenseId", "(LITERAL, MIT, @en)"},
+		}
+		if len(rdfParser.Triples) != len(expectedTriples) {
+			t.Errorf("expected %v triples, found %v: %v", len(expectedTriples), len(rdfParser.Triples), rdfParser.Triples)
+		}
+		for _, triple := range expectedTriples {
+			if !triples[triple] {
+				t.Errorf("expected triple %v not found in %v", triple, rdfParser.Triples)
+			}
+		}
+	}()
+
+	// TestCase 13: relative uris are resolved against the xml:base in scope
+	// or against the base uri of the document.
+	func() {
+		xmlBaseRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:File rdf:about="#SPDXRef-1">
+					<spdx:licenseConcluded rdf:resource="../licenses/MIT"/>
+				</spdx:File>
+				<spdx:File rdf:ID="SPDXRef-2" xml:base="http://spdx.org/other/doc2">
+					<spdx:fileName rdf:datatype="#string">main.c</spdx:fileName>
+					<spdx:checksum xml:base="checksums/">
+						<spdx:Checksum rdf:about="sha1"/>
+					</spdx:checksum>
+				</spdx:File>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(xmlBaseRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		rdfParser.BaseURI = "http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT"
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		triples := map[[3]string]bool{}
+		for _, triple := range rdfParser.Triples {
+			triples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] = true
+		}
+		expectedTriples := [][3]string{
+			{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://spdx.org/rdf/terms#licenseConcluded", "(RESOURCE, http://spdx.org/licenses/MIT)"},
+			{"(IRI, http://spdx.org/other/doc2#SPDXRef-2)", "http://spdx.org/rdf/terms#fileName", "(LITERAL, main.c, http://spdx.org/other/doc2#string)"},
+			{"(IRI, http://spdx.org/other/doc2#SPDXRef-2)", "http://spdx.org/rdf/terms#checksum", "(IRI, http://spdx.org/other/checksums/sha1)"},
+		}
+		for _, triple := range expectedTriples {
+			if !triples[triple] {
+				t.Errorf("expected triple %v not found in %v", triple, rdfParser.Triples)
+			}
+		}
+
+		// relative xml:base without any base uri of the document is an error.
+		xmlReader = xmlreaderFromString(`
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:File xml:base="relative/" rdf:about="file"/>
+			</rdf:RDF>`)
+		rootBlock, err = xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		err = New().Parse(rootBlock)
+		if err == nil {
+			t.Errorf("expected an error for a relative xml:base without any base uri")
+		}
+	}()
+
+	// TestCase 14: namespaces declared by the nested tags are in scope of
+	// the tag and its descendants only.
+	func() {
+		nestedNamespacesRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+				<spdx:File xmlns:spdx="http://spdx.org/rdf/terms#" rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+					<spdx:checksum>
+						<ck:Checksum xmlns:ck="http://spdx.org/rdf/terms#" xmlns:spdx="http://example.org/other#">
+							<spdx:algorithm>SHA1</spdx:algorithm>
+						</ck:Checksum>
+					</spdx:checksum>
+					<doap:name xmlns:doap="http://usefulinc.com/ns/doap#">main</doap:name>
+				</spdx:File>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(nestedNamespacesRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		predicates := map[string]bool{}
+		for _, triple := range rdfParser.Triples {
+			predicates[triple.Predicate.ID] = true
+		}
+		for _, predicate := range []string{"http://spdx.org/rdf/terms#checksum", "http://example.org/other#algorithm", "http://usefulinc.com/ns/doap#name"} {
+			if !predicates[predicate] {
+				t.Errorf("expected predicate %v in %v", predicate, rdfParser.Triples)
+			}
+		}
+		// nested prefixes are added to the schema definitions unless the
+		// prefix or the namespace is already in use.
+		for prefix, expected := range map[string]string{"spdx": "http://spdx.org/rdf/terms#", "doap": "http://usefulinc.com/ns/doap#"} {
+			uriref := rdfParser.SchemaDefinition[prefix]
+			if uriref.String() != expected {
+				t.Errorf("expected prefix %v to be mapped to %v, found %v", prefix, expected, uriref.String())
+			}
+		}
+		if _, exists := rdfParser.SchemaDefinition["ck"]; exists {
+			t.Errorf("namespace of prefix ck is already mapped to spdx")
+		}
+		// namespace manager of the parser has the same prefixes.
+		if bindings := rdfParser.Namespaces.Bindings(); !reflect.DeepEqual(bindings, rdfParser.SchemaDefinition) {
+			t.Errorf("expected the namespaces %v, found %v", rdfParser.SchemaDefinition, bindings)
+		}
+
+		// prefixes are not in scope outside the declaring tag.
+		xmlReader = xmlreaderFromString(`
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+				<spdx:File xmlns:spdx="http://spdx.org/rdf/terms#"/>
+				<spdx:Package/>
+			</rdf:RDF>`)
+		rootBlock, err = xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		err = New().Parse(rootBlock)
+		if err == nil {
+			t.Errorf("expected an error for the undefined schema name")
+		}
+	}()
+
+	// TestCase 15: rdf:li properties of a container are numbered separately
+	// for every node tag.
+	func() {
+		containerRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<rdf:Seq rdf:about="http://spdx.org/spdxdocs/doc#files">
+					<rdf:li rdf:resource="http://spdx.org/spdxdocs/doc#SPDXRef-1"/>
+					<rdf:li>second</rdf:li>
+					<spdx:name>files</spdx:name>
+					<rdf:li>
+						<rdf:Bag>
+							<rdf:li>inner</rdf:li>
+						</rdf:Bag>
+					</rdf:li>
+				</rdf:Seq>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(containerRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		seq := "(IRI, http://spdx.org/spdxdocs/doc#files)"
+		var bag *Node
+		triples := map[[3]string]bool{}
+		for _, triple := range rdfParser.Triples {
+			triples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] = true
+			if triple.Predicate.ID == RDFNS+"_3" {
+				bag = triple.Object
+			}
+			if triple.Predicate.ID == RDFNS+"li" {
+				t.Errorf("rdf:li must be replaced by a numbered property. found %v", triple)
+			}
+		}
+		if bag == nil {
+			t.Errorf("expected a rdf:_3 triple in %v", rdfParser.Triples)
+			return
+		}
+		expectedTriples := [][3]string{
+			{seq, RDFNS + "_1", "(RESOURCE, http://spdx.org/spdxdocs/doc#SPDXRef-1)"},
+			{seq, RDFNS + "_2", "(LITERAL, second)"},
+			{seq, "http://spdx.org/rdf/terms#name", "(LITERAL, files)"},
+			{bag.String(), RDFNS + "type", "(IRI, " + RDFNS + "Bag)"},
+			{bag.String(), RDFNS + "_1", "(LITERAL, inner)"},
+		}
+		for _, triple := range expectedTriples {
+			if !triples[triple] {
+				t.Errorf("expected triple %v not found in %v", triple, rdfParser.Triples)
+			}
+		}
+	}()
+
+	// TestCase 16: document without the rdf:RDF tag has a single node tag
+	// as the root tag.
+	func() {
+		singleNodeRDF := `
+			<spdx:SpdxDocument
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#"
+				rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT"
+				spdx:name="doc">
+				<spdx:describesPackage>
+					<spdx:Package rdf:about="#SPDXRef-1"/>
+				</spdx:describesPackage>
+			</spdx:SpdxDocument>`
+		xmlReader := xmlreaderFromString(singleNodeRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		rdfParser.BaseURI = "http://spdx.org/spdxdocs/doc"
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		document := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT)"
+		pkg := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)"
+		expectedTriples := map[[3]string]bool{
+			{document, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#SpdxDocument)"}: true,
+			{document, "http://spdx.org/rdf/terms#name", "(LITERAL, doc)"}:              true,
+			{document, "http://spdx.org/rdf/terms#describesPackage", pkg}:               true,
+			{pkg, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#Package)"}:           true,
+		}
+		if len(rdfParser.Triples) != len(expectedTriples) {
+			t.Errorf("expected %v triples, found %v: %v", len(expectedTriples), len(rdfParser.Triples), rdfParser.Triples)
+		}
+		for _, triple := range rdfParser.Triples {
+			if !expectedTriples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] {
+				t.Errorf("unexpected triple %v", triple)
+			}
+		}
+	}()
+
+	// TestCase 17: rdf:ID of a property tag reifies the triple of the property.
+	func() {
+		reificationRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#"
+				xmlns:rdfs="http://www.w3.org/2000/01/rdf-schema#">
+				<spdx:SpdxDocument rdf:about="#SPDXRef-DOCUMENT">
+					<spdx:relationship rdf:ID="rel-1">
+						<spdx:Relationship rdf:about="#SPDXRef-rel"/>
+					</spdx:relationship>
+					<spdx:name rdf:ID="name-1">doc</spdx:name>
+				</spdx:SpdxDocument>
+				<rdf:Statement rdf:about="#rel-1">
+					<rdfs:comment>annotation of the relationship</rdfs:comment>
+				</rdf:Statement>
+			</rdf:RDF>`
+		xmlReader := xmlreaderFromString(reificationRDF)
+		rootBlock, err := xmlReader.Read()
+		if err != nil {
+			t.Errorf("unexpected error reading a valid rdf file: %v", err)
+			return
+		}
+		rdfParser := New()
+		rdfParser.BaseURI = "http://spdx.org/spdxdocs/doc"
+		err = rdfParser.Parse(rootBlock)
+		if err != nil {
+			t.Errorf("error parsing a valid rdf file. Error: %v", err)
+		}
+		triples := map[[3]string]bool{}
+		for _, triple := range rdfParser.Triples {
+			triples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] = true
+		}
+		document := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT)"
+		rel := "(IRI, http://spdx.org/spdxdocs/doc#rel-1)"
+		name := "(IRI, http://spdx.org/spdxdocs/doc#name-1)"
+		expectedTriples := [][3]string{
+			{document, "http://spdx.org/rdf/terms#relationship", "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-rel)"},
+			{rel, RDFNS + "type", "(IRI, " + RDFNS + "Statement)"},
+			{rel, RDFNS + "subject", document},
+			{rel, RDFNS + "predicate", "(IRI, http://spdx.org/rdf/terms#relationship)"},
+			{rel, RDFNS + "object", "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-rel)"},
+			{rel, "http://www.w3.org/2000/01/rdf-schema#comment", "(LITERAL, annotation of the relationship)"},
+			{document, "http://spdx.org/rdf/terms#name", "(LITERAL, doc)"},
+			{name, RDFNS + "subject", document},
+			{name, RDFNS + "object", "(LITERAL, doc)"},
+		}
+		for _, triple := range expectedTriples {
+			if !triples[triple] {
+				t.Errorf("expected triple %v not found in %v", triple, rdfParser.Triples)
+			}
+		}
+	}()
+
+	// TestCase 18: literals are kept as they are unless they are normalized.
+	func() {
+		literalRDF := `
+			<rdf:RDF
+				xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+				xmlns:spdx="http://spdx.org/rdf/terms#">
+				<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+					<spdx:licenseText>
+  Licensed under the MIT license.
+</spdx:licenseText>
+					<spdx:comment>   </spdx:comment>
+					<spdx:noticeText></spdx:noticeText>
+				</spdx:File>
+			</rdf:RDF>`
+		expectedLiterals := map[bool]map[string]string{
+			false: {
+				"http://spdx.org/rdf/terms#licenseText": "\n  Licensed under the MIT license.\n",
+				"http://spdx.org/rdf/terms#comment":     "   ",
+				"http://spdx.org/rdf/terms#noticeText":  "",
+			},
+			true: {
+				"http://spdx.org/rdf/terms#licenseText": "Licensed under the MIT license.",
+				"http://spdx.org/rdf/terms#comment":     "",
+				"http://spdx.org/rdf/terms#noticeText":  "",
+			},
+		}
+		for normalize, expected := range expectedLiterals {
+			xmlReader := xmlreaderFromString(literalRDF)
+			rootBlock, err := xmlReader.Read()
+			if err != nil {
+				t.Errorf("unexpected error reading a valid rdf file: %v", err)
+				return
+			}
+			rdfParser := New()
+			rdfParser.NormalizeLiterals = normalize
+			err = rdfParser.Parse(rootBlock)
+			if err != nil {
+				t.Errorf("error parsing a valid rdf file. Error: %v", err)
+			}
+			for _, triple := range rdfParser.Triples {
+				if triple.Object.NodeType != LITERAL {
+					continue
+				}
+				if value := expected[triple.Predicate.ID]; triple.Object.ID != value {
+					t.Errorf("normalize=%v: expected %q for %v, found %q", normalize, value, triple.Predicate.ID, triple.Object.ID)
+				}
+			}
+		}
+	}()
+}
+
+func TestParser_ParseEach(t *testing.T) {
+	documentRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#"
+			xml:base="http://spdx.org/spdxdocs/doc">
+			<spdx:File rdf:about="#SPDXRef-1">
+				<spdx:fileName>a.go</spdx:fileName>
+				<spdx:fileName>a.go</spdx:fileName>
+			</spdx:File>
+			<spdx:File rdf:about="#SPDXRef-2" spdx:fileName="b.go"/>
+			<spdx:File rdf:about="#SPDXRef-1">
+				<spdx:fileName>a.go</spdx:fileName>
+			</spdx:File>
+		</rdf:RDF>`
+	// triples given by Parse are expected from ParseEach too.
+	xmlReader := xmlreaderFromString(documentRDF)
+	rootBlock, err := xmlReader.Read()
+	if err != nil {
+		t.Errorf("unexpected error reading a valid rdf file: %v", err)
+		return
+	}
+	rdfParser := New()
+	if err = rdfParser.Parse(rootBlock); err != nil {
+		t.Errorf("error parsing a valid rdf file. Error: %v", err)
+	}
+	expectedTriples := map[string]bool{}
+	for _, triple := range rdfParser.Triples {
+		expectedTriples[triple.Hash()] = true
+	}
+
+	// TestCase 1: without deduplication, triples are given as often as they
+	// are in the document.
+	// TestCase 2: with deduplication, every triple is given only once.
+	for _, dedup := range []bool{false, true} {
+		xmlReader = xmlreaderFromString(documentRDF)
+		rdfParser = New()
+		rdfParser.DedupStreamedTriples = dedup
+		var triples []*Triple
+		err = rdfParser.ParseEach(&xmlReader, func(triple *Triple) error {
+			triples = append(triples, triple)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("dedup=%v: unexpected error: %v", dedup, err)
+		}
+		if len(rdfParser.Triples) != 0 {
+			t.Errorf("dedup=%v: triples must not be stored in the parser, found %v", dedup, rdfParser.Triples)
+		}
+		streamedTriples := map[string]bool{}
+		for _, triple := range triples {
+			streamedTriples[triple.Hash()] = true
+		}
+		if !reflect.DeepEqual(streamedTriples, expectedTriples) {
+			t.Errorf("dedup=%v: expected triples %v, found %v", dedup, expectedTriples, streamedTriples)
+		}
+		if dedup && len(triples) != len(expectedTriples) {
+			t.Errorf("expected %v triples, found %v", len(expectedTriples), len(triples))
+		}
+		if !dedup && len(triples) <= len(expectedTriples) {
+			t.Errorf("expected duplicate triples without deduplication, found %v", triples)
+		}
+	}
+
+	// TestCase 3: error returned by the handler stops the parsing.
+	xmlReader = xmlreaderFromString(documentRDF)
+	nTriples := 0
+	err = New().ParseEach(&xmlReader, func(triple *Triple) error {
+		nTriples++
+		return errors.New("handler error")
+	})
+	if err == nil || err.Error() != "handler error" || nTriples != 1 {
+		t.Errorf("expected the handler error after the first triple, found %v after %v triples", err, nTriples)
+	}
+
+	// TestCase 4: root tag other than rdf:RDF is the only node tag.
+	xmlReader = xmlreaderFromString(`
+		<spdx:File xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#"
+			rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+			<spdx:fileName>a.go</spdx:fileName>
+		</spdx:File>`)
+	nTriples = 0
+	err = New().ParseEach(&xmlReader, func(triple *Triple) error {
+		nTriples++
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if nTriples != 2 {
+		t.Errorf("expected the rdf:type and spdx:fileName triples, found %v triples", nTriples)
+	}
+}
+
+// returns the triples of the document parsed by the rdfParser in the order
+// of the parser.Triples.
+func parseTriples(document string, rdfParser *Parser) ([][3]string, error) {
+	xmlReader := xmlreaderFromString(document)
+	rootBlock, err := xmlReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if err = rdfParser.Parse(rootBlock); err != nil {
+		return nil, err
+	}
+	var triples [][3]string
+	for _, triple := range rdfParser.Triples {
+		triples = append(triples, [3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()})
+	}
+	return triples, nil
+}
+
+func TestParser_Parse_concurrency(t *testing.T) {
+	// returns the triples of the document parsed by the given number of workers.
+	parse := func(document string, workers int) ([][3]string, error) {
+		rdfParser := New()
+		rdfParser.Workers = workers
+		return parseTriples(document, rdfParser)
+	}
+
+	// TestCase 1: triples are in the order of the document.
+	documentRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#"
+			xml:base="http://spdx.org/spdxdocs/doc">
+			<spdx:File rdf:about="#SPDXRef-1">
+				<spdx:checksum>
+					<spdx:Checksum>
+						<spdx:algorithm>SHA1</spdx:algorithm>
+					</spdx:Checksum>
+				</spdx:checksum>
+				<spdx:fileName>a.go</spdx:fileName>
+			</spdx:File>
+			<spdx:File rdf:about="#SPDXRef-2">
+				<spdx:fileName>b.go</spdx:fileName>
+			</spdx:File>
+		</rdf:RDF>`
+	file1 := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)"
+	file2 := "(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-2)"
+	expectedTriples := [][3]string{
+		{file1, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#File)"},
+		{file1, "http://spdx.org/rdf/terms#checksum", "(BNODE, N0)"},
+		{"(BNODE, N0)", RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#Checksum)"},
+		{"(BNODE, N0)", "http://spdx.org/rdf/terms#algorithm", "(LITERAL, SHA1)"},
+		{file1, "http://spdx.org/rdf/terms#fileName", "(LITERAL, a.go)"},
+		{file2, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#File)"},
+		{file2, "http://spdx.org/rdf/terms#fileName", "(LITERAL, b.go)"},
+	}
+	for _, workers := range []int{1, 4} {
+		triples, err := parse(documentRDF, workers)
+		if err != nil {
+			t.Errorf("workers=%v: unexpected error: %v", workers, err)
+		}
+		if !reflect.DeepEqual(triples, expectedTriples) {
+			t.Errorf("workers=%v: expected %v, found %v", workers, expectedTriples, triples)
+		}
+	}
+
+	// TestCase 2: triples and labels of the blank nodes are same every time
+	// a document with many nested blank nodes is parsed.
+	var document strings.Builder
+	document.WriteString(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">`)
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&document, `
+			<spdx:File>
+				<spdx:fileName>%d.go</spdx:fileName>
+				<spdx:checksum><spdx:Checksum spdx:algorithm="SHA1"/></spdx:checksum>
+				<spdx:checksum spdx:algorithm="SHA256"/>
+				<spdx:fileContributor rdf:parseType="Collection">
+					<spdx:Person/><spdx:Person><spdx:name>%d</spdx:name></spdx:Person>
+				</spdx:fileContributor>
+			</spdx:File>`, i, i)
+	}
+	document.WriteString("</rdf:RDF>")
+	expectedTriples, err := parse(document.String(), 1)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for run := 0; run < 20; run++ {
+		triples, err := parse(document.String(), 8)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(triples, expectedTriples) {
+			t.Errorf("run %v: triples differ from the triples parsed by a single worker", run)
+			break
+		}
+	}
+
+	// TestCase 3: error in any of the nested blocks is returned.
+	invalidDocument := strings.Replace(document.String(), "<spdx:name>25</spdx:name>", "<undefined:name>25</undefined:name>", 1)
+	for _, workers := range []int{1, 8} {
+		if _, err = parse(invalidDocument, workers); err == nil {
+			t.Errorf("workers=%v: expected an error parsing a tag with an undefined prefix", workers)
+		}
+	}
+}
+
+func TestParser_Parse_blankNodes(t *testing.T) {
+	documentRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:nodeID="N0">
+				<spdx:checksum>
+					<spdx:Checksum spdx:algorithm="SHA1"/>
+				</spdx:checksum>
+				<spdx:licenseConcluded rdf:nodeID="lic"/>
+			</spdx:File>
+			<spdx:License rdf:nodeID="lic" spdx:licenseId="MIT"/>
+		</rdf:RDF>`
+	// returns the triples of the document with the given labels of the
+	// file, checksum and license nodes.
+	expectedTriples := func(file, checksum, license, licenseObject string) [][3]string {
+		return [][3]string{
+			{file, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#File)"},
+			{file, "http://spdx.org/rdf/terms#checksum", checksum},
+			{checksum, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#Checksum)"},
+			{checksum, "http://spdx.org/rdf/terms#algorithm", "(LITERAL, SHA1)"},
+			{file, "http://spdx.org/rdf/terms#licenseConcluded", licenseObject},
+			{license, RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#License)"},
+			{license, "http://spdx.org/rdf/terms#licenseId", "(LITERAL, MIT)"},
+		}
+	}
+
+	// TestCase 1: labels of the document never collide with the generated labels.
+	triples, err := parseTriples(documentRDF, New())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := expectedTriples("(BNODE, _:N0)", "(BNODE, N0)", "(BNODE, _:lic)", "(BNODE, _:lic)")
+	if !reflect.DeepEqual(triples, expected) {
+		t.Errorf("expected %v, found %v", expected, triples)
+	}
+
+	// TestCase 2: labels of the document are replaced in the order of the document.
+	rdfParser := New()
+	rdfParser.RelabelBlankNodes = true
+	triples, err = parseTriples(documentRDF, rdfParser)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected = expectedTriples("(BNODE, N0)", "(BNODE, N1)", "(BNODE, N2)", "(BNODE, N2)")
+	if !reflect.DeepEqual(triples, expected) {
+		t.Errorf("expected %v, found %v", expected, triples)
+	}
+
+	// TestCase 3: skolemized blank nodes are IRIs.
+	genid := "http://example.com/.well-known/genid/"
+	rdfParser = New()
+	rdfParser.SkolemBase = genid
+	triples, err = parseTriples(documentRDF, rdfParser)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected = expectedTriples("(IRI, "+genid+"N0)", "(RESOURCE, "+genid+"N1)", "(IRI, "+genid+"N2)", "(RESOURCE, "+genid+"N2)")
+	expected[2][0], expected[3][0] = "(IRI, "+genid+"N1)", "(IRI, "+genid+"N1)"
+	if !reflect.DeepEqual(triples, expected) {
+		t.Errorf("expected %v, found %v", expected, triples)
+	}
+}
+
+func TestParser_Parse_slashNamespace(t *testing.T) {
+	// names of the tags are added to the path of a namespace ending in /
+	// and to the fragment of any other namespace.
+	documentRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:dcterms="http://purl.org/dc/terms/"
+			xmlns:spdx="http://spdx.org/rdf/terms">
+			<spdx:File rdf:about="http://example.com/file">
+				<dcterms:title>file</dcterms:title>
+			</spdx:File>
+		</rdf:RDF>`
+	triples, err := parseTriples(documentRDF, New())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := [][3]string{
+		{"(IRI, http://example.com/file)", RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#File)"},
+		{"(IRI, http://example.com/file)", "http://purl.org/dc/terms/title", "(LITERAL, file)"},
+	}
+	if !reflect.DeepEqual(triples, expected) {
+		t.Errorf("expected %v, found %v", expected, triples)
+	}
+}
+
+func TestParser_Parse_normalizeIRIs(t *testing.T) {
+	documentRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="HTTP://spdx.org/rdf/terms#">
+			<spdx:License rdf:about="HTTP://SPDX.org/licenses/MIT">
+				<spdx:seeAlso rdf:resource="http://spdx.org/licenses/./%4dIT"/>
+			</spdx:License>
+			<spdx:License rdf:about="http://spdx.org/licenses/MIT"/>
+		</rdf:RDF>`
+
+	// TestCase 1: IRIs are kept as they are by default.
+	triples, err := parseTriples(documentRDF, New())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(triples) != 3 {
+		t.Errorf("expected 3 triples, found %v", triples)
+	}
+
+	// TestCase 2: normalized IRIs are the same node.
+	rdfParser := New()
+	rdfParser.NormalizeIRIs = true
+	triples, err = parseTriples(documentRDF, rdfParser)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := [][3]string{
+		{"(IRI, http://spdx.org/licenses/MIT)", RDFNS + "type", "(IRI, http://spdx.org/rdf/terms#License)"},
+		{"(IRI, http://spdx.org/licenses/MIT)", "http://spdx.org/rdf/terms#seeAlso", "(RESOURCE, http://spdx.org/licenses/MIT)"},
+	}
+	if !reflect.DeepEqual(triples, expected) {
+		t.Errorf("expected %v, found %v", expected, triples)
+	}
+	if rdfParser.Triples[0].Subject != rdfParser.Triples[1].Subject {
+		t.Errorf("expected the triples to have the same subject node")
+	}
+}
+
+func TestParser_Parse_errorPosition(t *testing.T) {
+	// line and column of the tag having the error in the document.
+	errorPosition := func(document string) (line, column int) {
+		_, err := parseTriples(document, New())
+		var parseError *xmlreader.ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("expected a ParseError, found %v", err)
+			return 0, 0
+		}
+		return parseError.Position.Line, parseError.Position.Column
+	}
+
+	// TestCase 1: undefined prefix of a property tag.
+	line, column := errorPosition(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+	<rdf:Description>
+		<rdf:type rdf:resource="http://example.com/A"/>
+		<ex:name>A</ex:name>
+	</rdf:Description>
+</rdf:RDF>`)
+	if line != 4 || column != 3 {
+		t.Errorf("expected the error at line 4, column 3, found line %v, column %v", line, column)
+	}
+
+	// TestCase 2: undefined prefix of a nested node tag.
+	line, column = errorPosition(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+	<rdf:Description>
+		<rdf:value>
+			<ex:Thing/>
+		</rdf:value>
+	</rdf:Description>
+</rdf:RDF>`)
+	if line != 4 || column != 4 {
+		t.Errorf("expected the error at line 4, column 4, found line %v, column %v", line, column)
+	}
+
+	// TestCase 3: undefined prefix of a node tag of the rdf:RDF tag.
+	line, column = errorPosition(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description/>
+<ex:Thing rdf:about="http://example.com/thing"/>
+</rdf:RDF>`)
+	if line != 3 || column != 1 {
+		t.Errorf("expected the error at line 3, column 1, found line %v, column %v", line, column)
+	}
 }
 
 func Test_parseHeaderBlock(t *testing.T) {
@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"github.com/spdx/gordf/namespace/rdf"
 	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
 	"github.com/spdx/gordf/uri"
 	"strings"
@@ -21,12 +23,82 @@ func (parser *Parser) appendTriple(triple *Triple) {
 	//		can read from the data structure but at a time only one writer can
 	//		access the data structure.
 	parser.writeLock.Lock()
+	defer parser.writeLock.Unlock()
+	if parser.tripleHandler != nil {
+		// triples are given to the handler instead of being stored.
+		parser.handleTriple(triple)
+		return
+	}
 	if _, exists := parser.setTriples[triple.Hash()]; !exists {
 		// append to the map and triples' set if it doesn't already exist in the map.
 		parser.setTriples[triple.Hash()] = triple
 		parser.Triples = append(parser.Triples, triple)
 	}
-	parser.writeLock.Unlock()
+}
+
+func (parser *Parser) handleTriple(triple *Triple) {
+	// gives the triple to the tripleHandler of the parser.
+	// only the hash of the triple is stored for deduplicating the triples.
+	// writeLock must be held by the caller.
+	if parser.handlerErr != nil {
+		// no more triples are handled after the handler fails.
+		return
+	}
+	if parser.DedupStreamedTriples {
+		if _, exists := parser.setTriples[triple.Hash()]; exists {
+			return
+		}
+		parser.setTriples[triple.Hash()] = nil
+	}
+	parser.handlerErr = parser.tripleHandler(triple)
+}
+
+func (parser *Parser) appendTask(task *blockTask) {
+	// appends the triples of a parsed node block and of the node blocks
+	// nested in it in the order they are in the document.
+	for _, item := range task.items {
+		if item.nested != nil {
+			parser.appendTask(item.nested)
+			continue
+		}
+		parser.appendTriple(&Triple{
+			Subject:   parser.relabel(item.triple.Subject, IRI),
+			Predicate: item.triple.Predicate,
+			Object:    parser.relabel(item.triple.Object, RESOURCELITERAL),
+		})
+	}
+}
+
+func (parser *Parser) relabel(node *Node, skolemType NODETYPE) *Node {
+	// blank nodes are generated by the workers in no particular order.
+	// returns the blank node labelled in the order the blank nodes are
+	// appended so that the labels are same every time a document is parsed.
+	// blank nodes labelled by rdf:nodeID are returned as they are unless
+	// RelabelBlankNodes is true or SkolemBase is given.
+	// skolemType is the type of the IRI replacing a skolemized blank node.
+	if node.NodeType != BLANK {
+		return node
+	}
+	labels := parser.blankNodeLabels
+	if _, isNodeID := node.NodeID(); isNodeID {
+		if !parser.RelabelBlankNodes && parser.SkolemBase == "" {
+			return node
+		}
+		labels = parser.nodeIDLabels
+	}
+	labelled, exists := labels[node.ID]
+	if !exists {
+		blankNode := parser.documentBlankNodes.Get()
+		if parser.SkolemBase != "" {
+			blankNode = Node{NodeType: IRI, ID: parser.SkolemBase + blankNode.ID}
+		}
+		labelled = &blankNode
+		labels[node.ID] = labelled
+	}
+	if labelled.NodeType != BLANK && labelled.NodeType != skolemType {
+		return &Node{NodeType: skolemType, ID: labelled.ID}
+	}
+	return labelled
 }
 
 func (parser *Parser) resolveNode(node *Node) *Node {
@@ -41,23 +113,90 @@ func (parser *Parser) resolveNode(node *Node) *Node {
 	return node
 }
 
-func (parser *Parser) uriFromPair(schemaName, name string) (mergedUri uri.URIRef, err error) {
+func (s scope) uriFromPair(schemaName, name string) (mergedUri uri.URIRef, err error) {
 	// returns the uri representation of a pair of strings.
 	// name:schemaName is an example of pair.
 	// pairs such as rdf:RDF, where, rdf must be a valid xmlns schema name.
 
-	// base must be a valid schema name defined in the root tag.
-	baseURI, ok := parser.SchemaDefinition[schemaName]
+	// base must be a valid schema name declared by the tag or its ancestors.
+	baseURI, ok := s.namespaces[schemaName]
+	if !ok && schemaName == "xml" {
+		// xml prefix is bound to the XMLNS namespace without any declaration.
+		baseURI, _ = uri.NewURIRef(XMLNS)
+		ok = true
+	}
 	if !ok {
 		return uri.URIRef{}, fmt.Errorf("undefined schema name: %v", schemaName)
 	}
 
-	// adding the relative fragment to the base uri.
-	return baseURI.AddFragment(name), nil
+	// adding the name to the namespace uri.
+	mergedUri, err = baseURI.AddFragment(name)
+	if err != nil || !s.normalizeIRIs {
+		return mergedUri, err
+	}
+	return mergedUri.Normalize(), nil
+}
+
+// names of the attributes of rdf namespace which are a part of the rdf/xml
+// syntax. Such attributes are never property attributes.
+var rdfSyntaxAttributes = []string{
+	"about", "ID", "nodeID", "resource", "datatype", "parseType",
+	"bagID", "aboutEach", "aboutEachPrefix",
 }
 
-func (parser *Parser) convertRdfIdToRdfAbout(tag xmlreader.Tag) (error) {
-	idx, err := parser.getRDFAttributeIndex(tag, "ID")
+func (parser *Parser) getPropertyAttributes(tag xmlreader.Tag, tagScope scope) (predicates, objects []*Node, err error) {
+	// returns the predicates and objects of the property attributes of the tag.
+	// property attributes are abbreviation of the property tags having literal objects.
+	//   <spdx:Checksum spdx:algorithm="SHA1" spdx:checksumValue="abc"/>
+	// is same as:
+	//   <spdx:Checksum>
+	//       <spdx:algorithm>SHA1</spdx:algorithm>
+	//       <spdx:checksumValue>abc</spdx:checksumValue>
+	//   </spdx:Checksum>
+	// An exception is rdf:type attribute whose value is an IRI.
+	// language in the tagScope is attached to the literals and base uri in the
+	// tagScope resolves the relative value of rdf:type.
+	for _, attr := range tag.Attrs {
+		if attr.SchemaName == "" || attr.SchemaName == "xmlns" || attr.SchemaName == "xml" {
+			// unprefixed attributes, namespace declarations and
+			// xml attributes (like xml:lang) are not properties.
+			continue
+		}
+		predicateURI, err := tagScope.uriFromPair(attr.SchemaName, attr.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		predicate := predicateURI.String()
+		if strings.HasPrefix(predicate, rdf.NS) && any(strings.TrimPrefix(predicate, rdf.NS), rdfSyntaxAttributes) {
+			continue
+		}
+
+		object := &Node{NodeType: LITERAL, ID: attr.Value, Lang: tagScope.lang}
+		if predicate == rdf.Type.String() {
+			typeURI, err := tagScope.resolve(attr.Value)
+			if err != nil {
+				return nil, nil, err
+			}
+			object = &Node{NodeType: IRI, ID: typeURI}
+		}
+		predicates = append(predicates, &Node{NodeType: IRI, ID: predicate})
+		objects = append(objects, object)
+	}
+	return predicates, objects, nil
+}
+
+// return true if the target is in the given list
+func any(target string, list []string) bool {
+	for _, s := range list {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (parser *Parser) convertRdfIdToRdfAbout(tag xmlreader.Tag, tagScope scope) error {
+	idx, err := parser.getRDFAttributeIndex(tag, tagScope, "ID")
 	if err != nil {
 		return err
 	}
@@ -71,7 +210,33 @@ func (parser *Parser) convertRdfIdToRdfAbout(tag xmlreader.Tag) (error) {
 	return nil
 }
 
-func (parser *Parser) nodeFromTag(openingTag xmlreader.Tag, lastURI string) (node *Node, err error) {
+func (parser *Parser) statementFromTag(tag xmlreader.Tag, tagScope scope) (*Node, error) {
+	// returns the node given by the rdf:ID attribute of a property tag.
+	// the node reifies the triple of the property tag.
+	// returns nil if the property tag doesn't have a rdf:ID attribute.
+	idx, err := parser.getRDFAttributeIndex(tag, tagScope, "ID")
+	if err != nil || idx == -1 {
+		return nil, err
+	}
+	// rdf:ID="val" is same as the uri "#val" resolved in the scope of the tag.
+	statementURI, err := tagScope.resolve("#" + tag.Attrs[idx].Value)
+	if err != nil {
+		return nil, err
+	}
+	return parser.resolveNode(&Node{NodeType: IRI, ID: statementURI}), nil
+}
+
+// returns the error as a ParseError at the position of the tag.
+// errors which are already a ParseError keep their position.
+func tagError(tag xmlreader.Tag, err error) error {
+	var parseError *xmlreader.ParseError
+	if err == nil || errors.As(err, &parseError) {
+		return err
+	}
+	return &xmlreader.ParseError{Position: tag.Position, Err: err}
+}
+
+func (parser *Parser) nodeFromTag(openingTag xmlreader.Tag, parentScope scope) (node *Node, err error) {
 	// returns the node object from the opening tag of any block.
 	// https://www.w3.org/TR/rdf-syntax-grammar/figure1.png has sample image having 5 nodes.
 	// 		one of them is a blank node.
@@ -80,14 +245,23 @@ func (parser *Parser) nodeFromTag(openingTag xmlreader.Tag, lastURI string) (nod
 	// if the opening tag has an attribute of rdf:about,
 	//		the node will represented by the value of rdf:about attribute
 	// else, it is a blank node.
+	// relative uris are resolved in the scope of the tag.
+	defer func() {
+		err = tagError(openingTag, err)
+	}()
 
-	err = parser.convertRdfIdToRdfAbout(openingTag)
+	tagScope, err := parser.scopeOf(openingTag, parentScope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parser.convertRdfIdToRdfAbout(openingTag, tagScope)
 	if err != nil {
 		return nil, err
 	}
 
 	// checking if any of the attributes is a rdf:about attribute
-	index, err := parser.getRDFAttributeIndex(openingTag, "about")
+	index, err := parser.getRDFAttributeIndex(openingTag, tagScope, "about")
 	if err != nil {
 		return
 	}
@@ -96,21 +270,15 @@ func (parser *Parser) nodeFromTag(openingTag xmlreader.Tag, lastURI string) (nod
 	if index != -1 {
 		// we found a rdf:about tag.
 		currentNode.NodeType = IRI
-		currentNode.ID = openingTag.Attrs[index].Value
-		if strings.HasPrefix(currentNode.ID, "#") {
-			// the predicate uri is a relative uri. it must be resolve using the base lastURI
-			baseURI, err := uri.NewURIRef(lastURI)
-			if err != nil {
-				return nil, err
-			}
-			resolvedURI := baseURI.AddFragment(currentNode.ID)
-			currentNode.ID = resolvedURI.String()
+		currentNode.ID, err = tagScope.resolve(openingTag.Attrs[index].Value)
+		if err != nil {
+			return nil, err
 		}
 		return &currentNode, nil
 	}
 
 	// we don't have rdf:about attribute, returning a new blank node.
-	rdfNodeIDIndex, err := parser.getRDFAttributeIndex(openingTag, "nodeID")
+	rdfNodeIDIndex, err := parser.getRDFAttributeIndex(openingTag, tagScope, "nodeID")
 	if err != nil {
 		return nil, err
 	}
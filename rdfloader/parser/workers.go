@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"context"
+	"github.com/spdx/gordf/namespace/rdf"
+	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
+	"runtime"
+	"sync"
+)
+
+// a node block parsed by a worker.
+// the triples of the block are collected in the order they are in the
+// document. Node blocks nested in the block are parsed by other workers
+// and are kept at their position in the items of the block.
+type blockTask struct {
+	block *xmlreader.Block
+	node  *Node
+	scope scope // scope of the parent of the block.
+	items []taskItem
+}
+
+// either a triple or a nested node block of a blockTask.
+type taskItem struct {
+	triple *Triple
+	nested *blockTask
+}
+
+func (task *blockTask) addTriple(triple *Triple) {
+	task.items = append(task.items, taskItem{triple: triple})
+}
+
+func (task *blockTask) addNested(nested *blockTask) {
+	task.items = append(task.items, taskItem{nested: nested})
+}
+
+func (task *blockTask) addStatement(triple *Triple, statementNode *Node) {
+	// adds the triple and reifies it if the statementNode is not nil.
+	// A triple (s, p, o) reified by a statement node (st) results in:
+	//   (s)  -> (p)           -> (o)
+	//   (st) -> rdf:type      -> rdf:Statement
+	//   (st) -> rdf:subject   -> (s)
+	//   (st) -> rdf:predicate -> (p)
+	//   (st) -> rdf:object    -> (o)
+	task.addTriple(triple)
+	if statementNode == nil {
+		return
+	}
+	task.addTriple(&Triple{
+		Subject:   statementNode,
+		Predicate: &Node{NodeType: IRI, ID: rdf.Type.String()},
+		Object:    &Node{NodeType: IRI, ID: rdf.Statement.String()},
+	})
+	task.addTriple(&Triple{
+		Subject:   statementNode,
+		Predicate: &Node{NodeType: IRI, ID: rdf.Subject.String()},
+		Object:    triple.Subject,
+	})
+	task.addTriple(&Triple{
+		Subject:   statementNode,
+		Predicate: &Node{NodeType: IRI, ID: rdf.Predicate.String()},
+		Object:    triple.Predicate,
+	})
+	task.addTriple(&Triple{
+		Subject:   statementNode,
+		Predicate: &Node{NodeType: IRI, ID: rdf.Object.String()},
+		Object:    triple.Object,
+	})
+}
+
+// runs the node blocks of a document using a fixed number of goroutines.
+// the first error returned by a block cancels the blocks not yet parsed.
+type workerPool struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	tasks   chan *blockTask
+	run     func(task *blockTask) error
+	pending sync.WaitGroup // blocks submitted but not yet parsed.
+	errOnce sync.Once
+	err     error
+}
+
+func newWorkerPool(workers int, run func(task *blockTask) error) *workerPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := &workerPool{
+		ctx:    ctx,
+		cancel: cancel,
+		tasks:  make(chan *blockTask, workers),
+		run:    run,
+	}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for task := range pool.tasks {
+				pool.runTask(task)
+			}
+		}()
+	}
+	return pool
+}
+
+func (pool *workerPool) submit(task *blockTask) {
+	pool.pending.Add(1)
+	select {
+	case pool.tasks <- task:
+	default:
+		// the queue is full. Blocks are submitted by the workers too and
+		// waiting for a free worker could block all of them forever.
+		pool.runTask(task)
+	}
+}
+
+func (pool *workerPool) runTask(task *blockTask) {
+	defer pool.pending.Done()
+	if pool.ctx.Err() != nil {
+		// parsing is cancelled by an error in another block.
+		return
+	}
+	if err := pool.run(task); err != nil {
+		pool.fail(err)
+	}
+}
+
+// records the error if it is the first one and cancels the remaining blocks.
+func (pool *workerPool) fail(err error) {
+	pool.errOnce.Do(func() {
+		pool.err = err
+		pool.cancel()
+	})
+}
+
+// waits for all the submitted blocks, stops the workers and returns the
+// first error.
+func (pool *workerPool) wait() error {
+	pool.pending.Wait()
+	close(pool.tasks)
+	pool.cancel()
+	return pool.err
+}
@@ -1,27 +1,115 @@
 package rdfloader
 
 import (
-	"bufio"
 	"github.com/spdx/gordf/rdfloader/parser"
 	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
 	"io"
 	"os"
 )
 
+// configuration used for loading a document.
+type loader struct {
+	rdfParser *parser.Parser
+	// returns the reader of the xml content of the document. The content is
+	// converted to UTF-8 using the charsetReader.
+	newReader func(fileObj io.Reader, charsetReader xmlreader.CharsetReader) (xmlreader.Reader, error)
+	// converts the documents in the encodings unknown to the loader to UTF-8.
+	charsetReader xmlreader.CharsetReader
+}
+
+// Option customizes the reader and the parser used for loading a document.
+type Option func(l *loader)
+
+// WithBaseURI sets the uri of the document being loaded.
+// relative uris of the document are resolved against it unless an
+// xml:base attribute overrides it. baseURI must be an absolute uri.
+func WithBaseURI(baseURI string) Option {
+	return func(l *loader) {
+		l.rdfParser.BaseURI = baseURI
+	}
+}
+
+// WithLiteralNormalization removes the leading and trailing whitespaces of
+// the literals. By default, literals are loaded exactly as in the document.
+func WithLiteralNormalization() Option {
+	return func(l *loader) {
+		l.rdfParser.NormalizeLiterals = true
+	}
+}
+
+// WithIRINormalization normalizes the IRIs of the document so that the IRIs
+// differing only in the case of the scheme or the host, in percent-encoding,
+// in dot segments or in the Unicode normalization form are the same node.
+// By default, IRIs are loaded exactly as in the document.
+func WithIRINormalization() Option {
+	return func(l *loader) {
+		l.rdfParser.NormalizeIRIs = true
+	}
+}
+
+// WithTokenReader reads the document using the TokenReader which is built on
+// the encoding/xml package instead of the default XMLReader.
+func WithTokenReader() Option {
+	return func(l *loader) {
+		l.newReader = func(fileObj io.Reader, charsetReader xmlreader.CharsetReader) (xmlreader.Reader, error) {
+			reader, err := xmlreader.TokenReaderFromFileObjectWithCharsetReader(fileObj, charsetReader)
+			return &reader, err
+		}
+	}
+}
+
+// WithCharsetReader converts the documents in the encodings other than
+// UTF-8, UTF-16, ISO-8859-1 and US-ASCII to UTF-8 using the charsetReader.
+// charsetReader is called with the encoding declared by the document.
+func WithCharsetReader(charsetReader xmlreader.CharsetReader) Option {
+	return func(l *loader) {
+		l.charsetReader = charsetReader
+	}
+}
+
+// WithStreamDeduplication gives every triple to the handler of a streamed
+// document only once. It needs memory for the hashes of all the triples.
+func WithStreamDeduplication() Option {
+	return func(l *loader) {
+		l.rdfParser.DedupStreamedTriples = true
+	}
+}
+
+// WithBlankNodeRelabeling labels the blank nodes given by rdf:nodeID like the
+// blank nodes generated by the parser. By default, they keep the labels of
+// the document.
+func WithBlankNodeRelabeling() Option {
+	return func(l *loader) {
+		l.rdfParser.RelabelBlankNodes = true
+	}
+}
+
+// WithSkolemization replaces every blank node by an IRI made of the
+// skolemBase and a label generated by the parser. Like,
+//
+//	http://example.com/.well-known/genid/N0
+func WithSkolemization(skolemBase string) Option {
+	return func(l *loader) {
+		l.rdfParser.SkolemBase = skolemBase
+	}
+}
+
 // given a file path, parse it and return the Parser object
-func LoadFromFilePath(filePath string) (parserObj *parser.Parser, err error) {
+func LoadFromFilePath(filePath string, options ...Option) (parserObj *parser.Parser, err error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return
 	}
-	return LoadFromReaderObject(file)
+	return LoadFromReaderObject(file, options...)
 }
 
 // LoadFromReaderObj take an io.Reader object and returns a list of triples.
 //     if there is no error parsing the document.
-func LoadFromReaderObject(fileObj io.Reader) (parserObj *parser.Parser, err error) {
-	// reader for xml file
-	reader := xmlreader.XMLReaderFromFileObject(bufio.NewReader(fileObj))
+func LoadFromReaderObject(fileObj io.Reader, options ...Option) (parserObj *parser.Parser, err error) {
+	rdfParser, reader, err := newLoader(fileObj, options)
+	if err != nil {
+		return
+	}
 
 	// parsing the xml content of the file.
 	rootBlock, err := reader.Read()
@@ -29,11 +117,58 @@ func LoadFromReaderObject(fileObj io.Reader) (parserObj *parser.Parser, err erro
 		return
 	}
 
-	// creating a new Parser
-	rdfParser := parser.New()
 	err = rdfParser.Parse(rootBlock)
 	if err != nil {
 		return
 	}
 	return rdfParser, nil
 }
+
+// given a file path, parse it and give every triple to the handler.
+func StreamFromFilePath(filePath string, handler parser.TripleHandler, options ...Option) (parserObj *parser.Parser, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	return StreamFromReaderObject(file, handler, options...)
+}
+
+// StreamFromReaderObject parses the document without holding all of it in
+// memory. Every triple is given to the handler as soon as the node tag at
+// the top level of the document containing the triple is read.
+// The returned Parser has the schema definitions of the document but not
+// its triples. Duplicate triples are given to the handler more than once
+// unless the WithStreamDeduplication option is given.
+func StreamFromReaderObject(fileObj io.Reader, handler parser.TripleHandler, options ...Option) (parserObj *parser.Parser, err error) {
+	rdfParser, reader, err := newLoader(fileObj, options)
+	if err != nil {
+		return
+	}
+	err = rdfParser.ParseEach(reader, handler)
+	if err != nil {
+		return
+	}
+	return rdfParser, nil
+}
+
+// returns the parser and the reader of the document configured by the options.
+func newLoader(fileObj io.Reader, options []Option) (*parser.Parser, xmlreader.Reader, error) {
+	// creating a new Parser and the reader for xml file
+	l := loader{
+		rdfParser: parser.New(),
+		newReader: func(fileObj io.Reader, charsetReader xmlreader.CharsetReader) (xmlreader.Reader, error) {
+			reader, err := xmlreader.XMLReaderFromFileObjectWithCharsetReader(fileObj, charsetReader)
+			return &reader, err
+		},
+	}
+	for _, option := range options {
+		option(&l)
+	}
+	// content of the document is converted to UTF-8 before reading it.
+	reader, err := l.newReader(fileObj, l.charsetReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return l.rdfParser, reader, nil
+}
@@ -0,0 +1,274 @@
+package rdfloader
+
+import (
+	"github.com/spdx/gordf/rdfloader/parser"
+	xmlreader "github.com/spdx/gordf/rdfloader/xmlreader"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// returns the triples of the document loaded using the options.
+func loadTriples(t *testing.T, document string, options ...Option) map[[3]string]bool {
+	rdfParser, err := LoadFromReaderObject(strings.NewReader(document), options...)
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return nil
+	}
+	triples := map[[3]string]bool{}
+	for _, triple := range rdfParser.Triples {
+		triples[[3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()}] = true
+	}
+	return triples
+}
+
+// returns the triples given to the handler while streaming the document
+// using the options in the order they are given.
+func streamTriples(t *testing.T, document string, options ...Option) [][3]string {
+	var triples [][3]string
+	_, err := StreamFromReaderObject(strings.NewReader(document), func(triple *parser.Triple) error {
+		triples = append(triples, [3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()})
+		return nil
+	}, options...)
+	if err != nil {
+		t.Errorf("unexpected error streaming the document: %v", err)
+	}
+	return triples
+}
+
+// writes the document to a file in a new temporary directory. The directory
+// must be removed by the caller.
+func writeDocument(t *testing.T, document string) (dir, filePath string) {
+	dir, err := ioutil.TempDir("", "rdfloader")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	filePath = filepath.Join(dir, "doc.rdf")
+	if err = ioutil.WriteFile(filePath, []byte(document), 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dir, filePath
+}
+
+func TestOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+		options  []Option
+		// triple of the document loaded without and with the options.
+		// without is empty if the document can't be loaded without the
+		// options.
+		without, with [3]string
+	}{
+		{
+			name: "WithBaseURI",
+			document: `
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+					<spdx:File rdf:about="#SPDXRef-1"/>
+				</rdf:RDF>`,
+			options: []Option{WithBaseURI("http://spdx.org/spdxdocs/doc")},
+			with:    [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#File)"},
+		},
+		{
+			name: "WithLiteralNormalization",
+			document: `
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+					<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+						<spdx:fileName>  ./main.c </spdx:fileName>
+					</spdx:File>
+				</rdf:RDF>`,
+			options: []Option{WithLiteralNormalization()},
+			without: [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://spdx.org/rdf/terms#fileName", "(LITERAL,   ./main.c )"},
+			with:    [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://spdx.org/rdf/terms#fileName", "(LITERAL, ./main.c)"},
+		},
+		{
+			name: "WithBlankNodeRelabeling",
+			document: `
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+					<spdx:License rdf:nodeID="lic"/>
+				</rdf:RDF>`,
+			options: []Option{WithBlankNodeRelabeling()},
+			without: [3]string{"(BNODE, _:lic)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#License)"},
+			with:    [3]string{"(BNODE, N0)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#License)"},
+		},
+		{
+			name: "WithSkolemization",
+			document: `
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+					<spdx:License/>
+				</rdf:RDF>`,
+			options: []Option{WithSkolemization("http://example.com/.well-known/genid/")},
+			without: [3]string{"(BNODE, N0)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#License)"},
+			with:    [3]string{"(IRI, http://example.com/.well-known/genid/N0)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#License)"},
+		},
+		{
+			name: "WithIRINormalization",
+			document: `
+				<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+					<spdx:File rdf:about="HTTP://SPDX.org/spdxdocs/./doc#SPDXRef-1"/>
+				</rdf:RDF>`,
+			options: []Option{WithIRINormalization()},
+			without: [3]string{"(IRI, HTTP://SPDX.org/spdxdocs/./doc#SPDXRef-1)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#File)"},
+			with:    [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://www.w3.org/1999/02/22-rdf-syntax-ns#type", "(IRI, http://spdx.org/rdf/terms#File)"},
+		},
+	}
+	for _, testCase := range testCases {
+		// TestCase 1: option changes the triples of the loaded document.
+		if testCase.without == [3]string{} {
+			if _, err := LoadFromReaderObject(strings.NewReader(testCase.document)); err == nil {
+				t.Errorf("%v: expected an error loading the document without the option", testCase.name)
+			}
+		} else if triples := loadTriples(t, testCase.document); !triples[testCase.without] {
+			t.Errorf("%v: expected %v without the option, found %v", testCase.name, testCase.without, triples)
+		}
+		if triples := loadTriples(t, testCase.document, testCase.options...); !triples[testCase.with] {
+			t.Errorf("%v: expected %v with the option, found %v", testCase.name, testCase.with, triples)
+		}
+
+		// TestCase 2: option is used by both the readers and by the
+		// streamed documents.
+		loaded := loadTriples(t, testCase.document, testCase.options...)
+		for _, options := range [][]Option{testCase.options, append([]Option{WithTokenReader()}, testCase.options...)} {
+			if triples := loadTriples(t, testCase.document, options...); !reflect.DeepEqual(triples, loaded) {
+				t.Errorf("%v: expected %v, found %v", testCase.name, loaded, triples)
+			}
+			streamed := map[[3]string]bool{}
+			for _, triple := range streamTriples(t, testCase.document, options...) {
+				streamed[triple] = true
+			}
+			if !reflect.DeepEqual(streamed, loaded) {
+				t.Errorf("%v: expected %v to be streamed, found %v", testCase.name, loaded, streamed)
+			}
+		}
+	}
+}
+
+func TestWithTokenReader(t *testing.T) {
+	// TestCase 1: document is read by a TokenReader.
+	l := loader{}
+	WithTokenReader()(&l)
+	reader, err := l.newReader(strings.NewReader("<a/>"), nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, ok := reader.(*xmlreader.TokenReader); !ok {
+		t.Errorf("expected a TokenReader, found %T", reader)
+	}
+}
+
+func TestWithCharsetReader(t *testing.T) {
+	// 0x80 is the euro sign in windows-1252.
+	document := "<?xml version=\"1.0\" encoding=\"windows-1252\"?>\n" +
+		`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:Package rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+				<spdx:summary>costs 5 ` + "\x80" + `</spdx:summary>
+			</spdx:Package>
+		</rdf:RDF>`
+
+	// TestCase 1: windows-1252 is not supported without a charset reader.
+	if _, err := LoadFromReaderObject(strings.NewReader(document)); err == nil {
+		t.Errorf("expected an error stating the encoding is not supported")
+	}
+
+	// TestCase 2: charset reader converts the document to UTF-8 for both
+	// the readers.
+	var charsets []string
+	charsetReader := func(charset string, input io.Reader) (io.Reader, error) {
+		charsets = append(charsets, charset)
+		// the euro sign is the only non-ascii char of the document.
+		data, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(strings.Replace(string(data), "\x80", "€", -1)), nil
+	}
+	expected := [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://spdx.org/rdf/terms#summary", "(LITERAL, costs 5 €)"}
+	for _, options := range [][]Option{{WithCharsetReader(charsetReader)}, {WithCharsetReader(charsetReader), WithTokenReader()}} {
+		if triples := loadTriples(t, document, options...); !triples[expected] {
+			t.Errorf("expected %v, found %v", expected, triples)
+		}
+	}
+	if !reflect.DeepEqual(charsets, []string{"windows-1252", "windows-1252"}) {
+		t.Errorf("expected the charset reader to be called for windows-1252, found %v", charsets)
+	}
+}
+
+func TestWithStreamDeduplication(t *testing.T) {
+	// same triple is in two node tags at the top level.
+	document := `
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+				<spdx:fileName>./main.c</spdx:fileName>
+			</spdx:File>
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+				<spdx:fileName>./main.c</spdx:fileName>
+			</spdx:File>
+		</rdf:RDF>`
+	for _, readerOptions := range [][]Option{nil, {WithTokenReader()}} {
+		// TestCase 1: duplicate triples are streamed more than once by default.
+		if triples := streamTriples(t, document, readerOptions...); len(triples) != 4 {
+			t.Errorf("expected 4 triples, found %v", triples)
+		}
+
+		// TestCase 2: every triple is streamed only once.
+		options := append([]Option{WithStreamDeduplication()}, readerOptions...)
+		if triples := streamTriples(t, document, options...); len(triples) != 2 {
+			t.Errorf("expected 2 triples, found %v", triples)
+		}
+	}
+}
+
+func TestStreamFromFilePath(t *testing.T) {
+	dir, filePath := writeDocument(t, `
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+				<spdx:fileName> ./main.c </spdx:fileName>
+			</spdx:File>
+		</rdf:RDF>`)
+	defer os.RemoveAll(dir)
+	expected := [3]string{"(IRI, http://spdx.org/spdxdocs/doc#SPDXRef-1)", "http://spdx.org/rdf/terms#fileName", "(LITERAL, ./main.c)"}
+
+	// TestCase 1: options are used for streaming the file.
+	var streamed [][3]string
+	_, err := StreamFromFilePath(filePath, func(triple *parser.Triple) error {
+		streamed = append(streamed, [3]string{triple.Subject.String(), triple.Predicate.ID, triple.Object.String()})
+		return nil
+	}, WithLiteralNormalization())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(streamed) != 2 || streamed[1] != expected {
+		t.Errorf("expected %v to be streamed, found %v", expected, streamed)
+	}
+
+	// TestCase 2: inexistent file must raise an error.
+	if _, err = StreamFromFilePath(filepath.Join(dir, "none.rdf"), func(*parser.Triple) error { return nil }); err == nil {
+		t.Errorf("expected an error stating the file doesn't exist")
+	}
+}
+
+func TestLoadFromFilePath(t *testing.T) {
+	dir, filePath := writeDocument(t, `
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:about="#SPDXRef-1"/>
+		</rdf:RDF>`)
+	defer os.RemoveAll(dir)
+
+	// TestCase 1: options are used for loading the file.
+	rdfParser, err := LoadFromFilePath(filePath, WithBaseURI("http://spdx.org/spdxdocs/doc"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if len(rdfParser.Triples) != 1 || rdfParser.Triples[0].Subject.ID != "http://spdx.org/spdxdocs/doc#SPDXRef-1" {
+		t.Errorf("expected the subject to be http://spdx.org/spdxdocs/doc#SPDXRef-1, found %v", rdfParser.Triples)
+	}
+
+	// TestCase 2: inexistent file must raise an error.
+	if _, err = LoadFromFilePath(filepath.Join(dir, "none.rdf")); err == nil {
+		t.Errorf("expected an error stating the file doesn't exist")
+	}
+}
@@ -0,0 +1,162 @@
+package rdfloader
+
+/**
+ * This module converts the content of a document to UTF-8 before reading it.
+ * Encoding of the document is given by its byte order mark or by the
+ * encoding declaration of its xml declaration:
+ *		<?xml version="1.0" encoding="ISO-8859-1"?>
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// CharsetReader returns a reader which converts the input encoded in the
+// given charset to UTF-8. It is same as the CharsetReader of xml.Decoder.
+type CharsetReader func(charset string, input io.Reader) (io.Reader, error)
+
+// matches the encoding declaration of a xml declaration.
+var encodingDeclRegex = regexp.MustCompile(`\sencoding\s*=\s*["']([A-Za-z][A-Za-z0-9._-]*)["']`)
+
+// transcodes the runes returned by the next function to UTF-8.
+type decodingReader struct {
+	next    func() (rune, error)
+	pending []byte // encoded bytes which are not yet read.
+}
+
+func (r *decodingReader) Read(p []byte) (n int, err error) {
+	for len(r.pending) < len(p) {
+		ch, err := r.next()
+		if err != nil {
+			if len(r.pending) == 0 {
+				return 0, err
+			}
+			// error is reported by the next call.
+			break
+		}
+		var buffer [utf8.UTFMax]byte
+		r.pending = append(r.pending, buffer[:utf8.EncodeRune(buffer[:], ch)]...)
+	}
+	n = copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// returns a reader of the ISO-8859-1 encoded input as UTF-8.
+// Every byte of ISO-8859-1 is the code point of the character.
+func latin1Reader(input *bufio.Reader) io.Reader {
+	return &decodingReader{next: func() (rune, error) {
+		b, err := input.ReadByte()
+		return rune(b), err
+	}}
+}
+
+// returns a reader of the UTF-16 encoded input as UTF-8.
+// bigEndian gives the byte order of the code units.
+func utf16Reader(input *bufio.Reader, bigEndian bool) io.Reader {
+	readUnit := func() (rune, error) {
+		var unit [2]byte
+		if _, err := io.ReadFull(input, unit[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return 0, fmt.Errorf("incomplete UTF-16 code unit at the end of the document")
+			}
+			return 0, err
+		}
+		if bigEndian {
+			return rune(unit[0])<<8 | rune(unit[1]), nil
+		}
+		return rune(unit[1])<<8 | rune(unit[0]), nil
+	}
+	return &decodingReader{next: func() (rune, error) {
+		r, err := readUnit()
+		if err != nil || !utf16.IsSurrogate(r) {
+			return r, err
+		}
+		// characters outside the basic multilingual plane are given
+		// by a pair of surrogates.
+		low, err := readUnit()
+		if err == io.EOF {
+			return 0, fmt.Errorf("incomplete UTF-16 surrogate pair at the end of the document")
+		}
+		return utf16.DecodeRune(r, low), err
+	}}
+}
+
+// returns the encoding declared by the xml declaration at the start of the
+// input. returns an empty string if there is no declaration or if the
+// declaration doesn't have an encoding.
+func declaredEncoding(input *bufio.Reader) (string, error) {
+	prefix, _ := input.Peek(len("<?xml "))
+	if len(prefix) < len("<?xml ") || string(prefix[:5]) != "<?xml" || !bytes.ContainsAny(prefix[5:], " \t\r\n") {
+		return "", nil
+	}
+	for size := 64; ; size *= 2 {
+		peeked, err := input.Peek(size)
+		if idx := bytes.Index(peeked, []byte("?>")); idx != -1 {
+			match := encodingDeclRegex.FindSubmatch(peeked[:idx])
+			if match == nil {
+				return "", nil
+			}
+			return string(match[1]), nil
+		}
+		if err != nil {
+			// reached the end of the input or the size of the buffer.
+			return "", fmt.Errorf("expected ?> at the end of the xml declaration")
+		}
+	}
+}
+
+// NewUTF8Reader returns a reader of the document converted to UTF-8.
+// Encoding is detected by the byte order mark of the document or by the
+// encoding declared in its xml declaration. UTF-8, UTF-16, ISO-8859-1 and
+// US-ASCII are supported by default. Documents in any other encoding are
+// converted by the charsetReader. charsetReader may be nil.
+func NewUTF8Reader(input io.Reader, charsetReader CharsetReader) (*bufio.Reader, error) {
+	reader := bufio.NewReader(input)
+	start, _ := reader.Peek(4)
+	switch {
+	case bytes.HasPrefix(start, []byte{0xEF, 0xBB, 0xBF}):
+		// byte order mark of UTF-8 isn't a part of the content.
+		reader.Discard(3)
+		return reader, nil
+	case bytes.HasPrefix(start, []byte{0xFE, 0xFF}):
+		reader.Discard(2)
+		return bufio.NewReader(utf16Reader(reader, true)), nil
+	case bytes.HasPrefix(start, []byte{0xFF, 0xFE}):
+		reader.Discard(2)
+		return bufio.NewReader(utf16Reader(reader, false)), nil
+	case bytes.Equal(start, []byte{0x00, '<', 0x00, '?'}):
+		// UTF-16 big endian document without a byte order mark.
+		return bufio.NewReader(utf16Reader(reader, true)), nil
+	case bytes.Equal(start, []byte{'<', 0x00, '?', 0x00}):
+		// UTF-16 little endian document without a byte order mark.
+		return bufio.NewReader(utf16Reader(reader, false)), nil
+	}
+
+	encoding, err := declaredEncoding(reader)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(encoding) {
+	case "", "utf-8", "utf8":
+		return reader, nil
+	case "iso-8859-1", "iso8859-1", "iso_8859-1", "latin1", "l1", "us-ascii", "ascii":
+		// US-ASCII is a subset of ISO-8859-1.
+		return bufio.NewReader(latin1Reader(reader)), nil
+	}
+	if charsetReader == nil {
+		return nil, fmt.Errorf("unsupported encoding %v", encoding)
+	}
+	converted, err := charsetReader(encoding, reader)
+	if err != nil {
+		return nil, err
+	}
+	return bufio.NewReader(converted), nil
+}
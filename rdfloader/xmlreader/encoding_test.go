@@ -0,0 +1,186 @@
+package rdfloader
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+// returns the content read by the NewUTF8Reader from the input.
+func readUTF8(input []byte, charsetReader CharsetReader) (string, error) {
+	reader, err := NewUTF8Reader(bytes.NewReader(input), charsetReader)
+	if err != nil {
+		return "", err
+	}
+	content, err := ioutil.ReadAll(reader)
+	return string(content), err
+}
+
+// returns the UTF-16 encoding of the text with an optional byte order mark.
+func encodeUTF16(text string, bigEndian, withBOM bool) []byte {
+	units := utf16.Encode([]rune(text))
+	if withBOM {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	var output []byte
+	for _, unit := range units {
+		if bigEndian {
+			output = append(output, byte(unit>>8), byte(unit))
+		} else {
+			output = append(output, byte(unit), byte(unit>>8))
+		}
+	}
+	return output
+}
+
+func TestNewUTF8Reader(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-16"?><spdx:name>Jürgen 😀</spdx:name>`
+
+	// TestCase 1: UTF-8 content is read as it is except its byte order mark.
+	output, err := readUTF8(append([]byte{0xEF, 0xBB, 0xBF}, "<a>Jürgen</a>"...), nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if output != "<a>Jürgen</a>" {
+		t.Errorf("expected %v, found %v", "<a>Jürgen</a>", output)
+	}
+
+	// TestCase 2: UTF-16 content is detected by the byte order mark or by
+	// the xml declaration and is converted to UTF-8.
+	for _, bigEndian := range []bool{true, false} {
+		for _, withBOM := range []bool{true, false} {
+			output, err = readUTF8(encodeUTF16(document, bigEndian, withBOM), nil)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if output != document {
+				t.Errorf("bigEndian=%v, withBOM=%v: expected %v, found %v", bigEndian, withBOM, document, output)
+			}
+		}
+	}
+
+	// TestCase 3: ISO-8859-1 content is converted to UTF-8.
+	latin1Document := []byte("<?xml version='1.0' encoding='ISO-8859-1'?>\n<spdx:name>J\xfcrgen \xa9</spdx:name>")
+	output, err = readUTF8(latin1Document, nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expected := "<?xml version='1.0' encoding='ISO-8859-1'?>\n<spdx:name>Jürgen ©</spdx:name>"; output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+
+	// TestCase 4: other encodings are converted by the charset reader.
+	if _, err = readUTF8([]byte(`<?xml version="1.0" encoding="windows-1252"?><a/>`), nil); err == nil {
+		t.Error("expected an error for an unsupported encoding")
+	}
+	var charset string
+	charsetReader := func(name string, input io.Reader) (io.Reader, error) {
+		charset = name
+		return input, nil
+	}
+	output, err = readUTF8([]byte(`<?xml version="1.0" encoding="windows-1252"?><a/>`), charsetReader)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if charset != "windows-1252" || !strings.HasSuffix(output, "<a/>") {
+		t.Errorf("expected the charset reader to be called for windows-1252, found %v with output %v", charset, output)
+	}
+
+	// TestCase 5: xml declaration without ?> must raise an error.
+	if _, err = readUTF8([]byte(`<?xml version="1.0" encoding="ISO-8859-1"`), nil); err == nil {
+		t.Error("expected an error for an unterminated xml declaration")
+	}
+}
+
+// returns the readers of the input converted to UTF-8 by the charsetReader.
+func readersWithCharsetReader(input []byte, charsetReader CharsetReader) (map[string]Reader, error) {
+	xmlReader, err := XMLReaderFromFileObjectWithCharsetReader(bytes.NewReader(input), charsetReader)
+	if err != nil {
+		return nil, err
+	}
+	tokenReader, err := TokenReaderFromFileObjectWithCharsetReader(bytes.NewReader(input), charsetReader)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]Reader{
+		"XMLReader":   &xmlReader,
+		"TokenReader": &tokenReader,
+	}, nil
+}
+
+func TestReadersWithCharsetReader(t *testing.T) {
+	latin1Document := "<?xml version='1.0' encoding='ISO-8859-1'?>\n<spdx:name>J\xfcrgen \xa9</spdx:name>"
+
+	// TestCase 1: readers ignore the encoding declaration of the content
+	// converted to UTF-8.
+	readers, err := readersWithCharsetReader([]byte(latin1Document), nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for name, reader := range readers {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if block.Value != "Jürgen ©" {
+			t.Errorf("%v: expected %v, found %v", name, "Jürgen ©", block.Value)
+		}
+	}
+
+	// TestCase 2: content read from a file path is converted to UTF-8.
+	testFile, err := InitTestFile(latin1Document)
+	if err != nil {
+		t.Errorf(err.Error())
+		return
+	}
+	defer testFile.Delete()
+	xmlReader, err := XMLReaderFromFilePath(testFile.name)
+	if err != nil {
+		t.Errorf("XMLReader: unexpected error: %v", err)
+	}
+	defer xmlReader.CloseFileObj()
+	tokenReader, err := TokenReaderFromFilePath(testFile.name)
+	if err != nil {
+		t.Errorf("TokenReader: unexpected error: %v", err)
+	}
+	defer tokenReader.CloseFileObj()
+	for name, reader := range map[string]Reader{"XMLReader": &xmlReader, "TokenReader": &tokenReader} {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if block.Value != "Jürgen ©" {
+			t.Errorf("%v: expected %v, found %v", name, "Jürgen ©", block.Value)
+		}
+	}
+
+	// TestCase 3: a TokenReader of content that is not converted to UTF-8
+	// must report the encoding declared by the document.
+	tokenReader = TokenReaderFromFileObject(bufio.NewReader(strings.NewReader(latin1Document)))
+	if _, err = tokenReader.Read(); err == nil || !strings.Contains(err.Error(), "ISO-8859-1") {
+		t.Errorf("expected an error for the ISO-8859-1 encoding, found %v", err)
+	}
+
+	// TestCase 4: other encodings are converted by the charset reader.
+	charsetReader := func(name string, input io.Reader) (io.Reader, error) {
+		content, err := ioutil.ReadAll(input)
+		return strings.NewReader(strings.Replace(string(content), "\x80", "€", -1)), err
+	}
+	readers, err = readersWithCharsetReader([]byte("<?xml version=\"1.0\" encoding=\"windows-1252\"?><a>\x80</a>"), charsetReader)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for name, reader := range readers {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if block.Value != "€" {
+			t.Errorf("%v: expected %v, found %v", name, "€", block.Value)
+		}
+	}
+}
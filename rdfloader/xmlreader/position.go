@@ -0,0 +1,100 @@
+package rdfloader
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Position is the location of a character in a document.
+// Offset is the number of bytes before the character in the UTF-8 content of
+// the document. Line and Column start at 1. Column counts the characters
+// and not the bytes.
+type Position struct {
+	Offset int64
+	Line   int
+	Column int
+}
+
+func (position Position) String() string {
+	return fmt.Sprintf("line %d, column %d", position.Line, position.Column)
+}
+
+// ParseError is an error found at a position of a document.
+// Use errors.As to get the position of an error returned by a reader or by
+// the parser.
+type ParseError struct {
+	Position Position
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Position, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// returns the error as a ParseError at the given position.
+// errors which are already a ParseError keep their position.
+func errorAt(position Position, err error) error {
+	var parseError *ParseError
+	if err == nil || errors.As(err, &parseError) {
+		return err
+	}
+	return &ParseError{Position: position, Err: err}
+}
+
+// handlers given to ReadEach. Remembers the last error returned by them so
+// that it isn't reported as an error in the document.
+type eachHandlers struct {
+	handleRoot  func(rootBlock Block) error
+	handleChild func(childBlock Block) error
+	err         error
+}
+
+func (handlers *eachHandlers) root(rootBlock Block) error {
+	handlers.err = handlers.handleRoot(rootBlock)
+	return handlers.err
+}
+
+func (handlers *eachHandlers) child(childBlock Block) error {
+	handlers.err = handlers.handleChild(childBlock)
+	return handlers.err
+}
+
+// returns the error of ReadEach. errors other than that of the handlers are
+// returned as a ParseError at the given position.
+func (handlers *eachHandlers) errorAt(position Position, err error) error {
+	if err != nil && err == handlers.err {
+		return err
+	}
+	return errorAt(position, err)
+}
+
+// counts the bytes, lines and characters read from a document.
+type positionCounter struct {
+	offset  int64
+	lines   int // number of line feeds read.
+	columns int // number of characters read after the last line feed.
+}
+
+func (counter *positionCounter) advance(text []byte) {
+	for _, b := range text {
+		counter.offset++
+		switch {
+		case b == '\n':
+			counter.lines++
+			counter.columns = 0
+		case utf8.RuneStart(b):
+			// continuation bytes of a multi-byte character are not counted.
+			counter.columns++
+		}
+	}
+}
+
+// returns the position of the next character to be read.
+func (counter *positionCounter) position() Position {
+	return Position{Offset: counter.offset, Line: counter.lines + 1, Column: counter.columns + 1}
+}
@@ -0,0 +1,73 @@
+package rdfloader
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPositionCounter_advance(t *testing.T) {
+	// TestCase 1: multi-byte characters are counted once in the column.
+	counter := positionCounter{}
+	counter.advance([]byte("a©b\n\tc"))
+	expected := Position{Offset: 7, Line: 2, Column: 3}
+	if counter.position() != expected {
+		t.Errorf("expected %#v, found %#v", expected, counter.position())
+	}
+}
+
+func TestReader_positions(t *testing.T) {
+	// TestCase 1: every tag has the position of its '<' char.
+	testString := `<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+    <spdx:License rdf:about="©">  <spdx:name>Apache</spdx:name>
+    </spdx:License>
+</rdf:RDF>`
+	expected := []Position{
+		{Offset: 22, Line: 2, Column: 1},
+		{Offset: 132, Line: 3, Column: 5},
+		{Offset: 163, Line: 3, Column: 35},
+	}
+	for name, reader := range readersFromString(testString) {
+		rootBlock, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		license := rootBlock.Children[0]
+		found := []Position{rootBlock.Position(), license.Position(), license.Children[0].Position()}
+		if !reflect.DeepEqual(found, expected) {
+			t.Errorf("%v: expected %#v, found %#v", name, expected, found)
+		}
+	}
+
+	// TestCase 2: mismatching closing tag is reported at its position.
+	testString = `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+    <rdf:Description>
+    </rdf:Desc>
+</rdf:RDF>`
+	for name, reader := range readersFromString(testString) {
+		_, err := reader.Read()
+		var parseError *ParseError
+		if !errors.As(err, &parseError) {
+			t.Errorf("%v: expected a ParseError, found %v", name, err)
+			continue
+		}
+		if parseError.Position.Line != 3 || parseError.Position.Column != 5 {
+			t.Errorf("%v: expected the error at line 3, column 5, found %v", name, parseError.Position)
+		}
+	}
+
+	// TestCase 3: errors of the handlers of ReadEach are returned as they are.
+	handlerErr := errors.New("handler error")
+	for name, reader := range readersFromString(`<rdf:RDF><a/></rdf:RDF>`) {
+		err := reader.ReadEach(func(rootBlock Block) error {
+			return nil
+		}, func(childBlock Block) error {
+			return handlerErr
+		})
+		if err != handlerErr {
+			t.Errorf("%v: expected %v, found %v", name, handlerErr, err)
+		}
+	}
+}
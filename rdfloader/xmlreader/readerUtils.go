@@ -2,8 +2,12 @@ package rdfloader
 
 import (
 	"bufio"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 const WHITESPACE uint64 = 1<<'\t' | 1<<'\n' | 1<<'\r' | 1<<' '
@@ -11,6 +15,14 @@ const WHITESPACE uint64 = 1<<'\t' | 1<<'\n' | 1<<'\r' | 1<<' '
 type XMLReader struct {
 	fileReader *bufio.Reader
 	fileObj    *os.File
+	// namespace declarations (xmlns attributes) of the tags enclosing the
+	// block being read. Last element belongs to the innermost tag.
+	namespaces [][]Attribute
+	// general entities declared in the internal subset of the DOCTYPE.
+	// maps the name of the entity to its replacement text.
+	entities map[string]string
+	// position of the next character to be read.
+	counter positionCounter
 }
 
 /*
@@ -39,6 +51,8 @@ type Tag struct {
 	SchemaName string
 	Name       string
 	Attrs      []Attribute
+	// position of the '<' starting the tag in the document.
+	Position Position
 }
 
 type Block struct {
@@ -53,6 +67,19 @@ type Block struct {
 	Children   []*Block
 }
 
+// Position returns the position of the opening tag of the block.
+func (block *Block) Position() Position {
+	return block.OpeningTag.Position
+}
+
+// Reader reads a xml document into a tree of blocks.
+// ReadEach reads the children of the root block one at a time.
+// XMLReader and TokenReader are the implementations of a Reader.
+type Reader interface {
+	Read() (rootBlock Block, err error)
+	ReadEach(handleRoot func(rootBlock Block) error, handleChild func(childBlock Block) error) error
+}
+
 // returns next character in the file without affecting the file pointer
 func (xmlReader *XMLReader) peekARune() (r rune, err error) {
 	singleByte, err := xmlReader.fileReader.Peek(1)
@@ -66,7 +93,8 @@ func (xmlReader *XMLReader) peekARune() (r rune, err error) {
 // returns next character in the file which advances the file pointer.
 func (xmlReader *XMLReader) readARune() (rune, error) {
 	singleByteArray := make([]byte, 1)
-	_, err := xmlReader.fileReader.Read(singleByteArray)
+	n, err := xmlReader.fileReader.Read(singleByteArray)
+	xmlReader.counter.advance(singleByteArray[:n])
 	return rune(singleByteArray[0]), err
 }
 
@@ -74,23 +102,25 @@ func (xmlReader *XMLReader) readTill(delim uint64) ([]rune, error) {
 	// reads the input file rune by rune till the target rune is found
 	//		or eof is reached.
 	// Note: it doesn't include the target rune in the read word.
-	var buffer []rune
+	// delimiters are ascii characters. So, the input is read byte by byte
+	// and the bytes are decoded as utf-8 once the delimiter is found.
+	var buffer []byte
 	for {
 		r, err := xmlReader.fileReader.Peek(1)
 		if err == nil {
 			// checking if the read rune is same as any of the delimiters' mask
 			if (delim & (1 << r[0])) != 0 {
 				// current char is same as one of the delimiters.
-				return buffer, nil
+				return []rune(string(buffer)), nil
 			}
 
 			// moving file pointer one character ahead.
 			xmlReader.readARune()
 
 			// current character is not one of the delimiters.
-			buffer = append(buffer, rune(r[0]))
+			buffer = append(buffer, r[0])
 		} else {
-			return buffer, err
+			return []rune(string(buffer)), err
 		}
 	}
 }
@@ -111,7 +141,7 @@ func (xmlReader *XMLReader) readTillString(delimiter string) ([]byte, error) {
 			return buffer, nil
 		}
 
-		// moving file pointer n characters ahead.
+		// moving file pointer one character ahead.
 		xmlReader.readARune()
 
 		// current string doesn't match the given delimiter.
@@ -130,6 +160,7 @@ func (xmlReader *XMLReader) readNBytes(n int) ([]byte, error) {
 	for n > 0 {
 		buffer := make([]byte, n)
 		nBytesRead, err := xmlReader.fileReader.Read(buffer)
+		xmlReader.counter.advance(buffer[:nBytesRead])
 		if err != nil {
 			return output, err
 		}
@@ -144,10 +175,365 @@ func (xmlReader *XMLReader) ignoreWhiteSpace() (nWS int, err error) {
 	// nWS: number of whitespaces which were stripped.
 	for {
 		char, err := xmlReader.peekARune()
-		if err != nil || !unicode.IsSpace(char) {
+		// bytes of multi-byte utf-8 characters are never whitespaces.
+		if err != nil || char >= utf8.RuneSelf || !unicode.IsSpace(char) {
 			return nWS, err
 		}
 		nWS++
 		xmlReader.readARune()
 	}
 }
+
+// returns true if the target is in the given list
+func contains(list []string, target string) bool {
+	for _, s := range list {
+		if s == target {
+			return true
+		}
+	}
+	return false
+}
+
+// reports an error if the closing tag doesn't match the opening tag.
+func matchingTags(openingTag, closingTag Tag) error {
+	if openingTag.Name != closingTag.Name || openingTag.SchemaName != closingTag.SchemaName {
+		// opening and closing tags are not same.
+		err := fmt.Errorf("opening and closing tags doesn't match: opening tag; %v:%v, closing tag: %v:%v.", openingTag.SchemaName, openingTag.Name, closingTag.SchemaName, closingTag.Name)
+		return errorAt(closingTag.Position, err)
+	}
+	return nil
+}
+
+// error for a text found along with the children tags of a block.
+// whitespaces between the tags are insignificant but a text isn't.
+func mixedContentError(tag Tag, text string) error {
+	return fmt.Errorf("found text %q mixed with the tags of %v:%v", text, tag.SchemaName, tag.Name)
+}
+
+// returns the xmlns attributes of the tag.
+// both xmlns:prefix="uri" and xmlns="uri" are namespace declarations.
+func namespaceDeclarations(tag Tag) (declarations []Attribute) {
+	for _, attr := range tag.Attrs {
+		if attr.SchemaName == "xmlns" || (attr.SchemaName == "" && attr.Name == "xmlns") {
+			declarations = append(declarations, attr)
+		}
+	}
+	return declarations
+}
+
+// returns a map from prefix to the namespace uri of all the namespaces
+// declared by the tags enclosing the current block. stack has the namespace
+// declarations of the enclosing tags with the innermost tag at the end.
+// default namespace is mapped by an empty prefix.
+func inScopeNamespaces(stack [][]Attribute) map[string]string {
+	namespaces := map[string]string{}
+	// outer declarations are overridden by the inner ones.
+	for _, declarations := range stack {
+		for _, attr := range declarations {
+			if attr.SchemaName == "" {
+				namespaces[""] = attr.Value
+			} else {
+				namespaces[attr.Name] = attr.Value
+			}
+		}
+	}
+	return namespaces
+}
+
+// reads a raw tag starting with '<' and ending with '>'.
+// '>' inside the quoted attribute values doesn't end the tag.
+func (xmlReader *XMLReader) readRawTag() ([]byte, error) {
+	var buffer []byte
+	var quote byte
+	for {
+		b, err := xmlReader.fileReader.ReadByte()
+		if err != nil {
+			return buffer, err
+		}
+		xmlReader.counter.advance([]byte{b})
+		buffer = append(buffer, b)
+		switch {
+		case quote != 0:
+			if b == quote {
+				// end of the quoted attribute value
+				quote = 0
+			}
+		case b == '"' || b == '\'':
+			quote = b
+		case b == '>':
+			return buffer, nil
+		}
+	}
+}
+
+// returns the prefix of the element name and of the attributes of a raw
+// opening or closing tag. A raw tag is of the form:
+//
+//	<prefix:name prefix2:attr="value" ...>
+//
+// declared is the list of prefixes declared by xmlns attributes of the tag.
+// an unprefixed tag uses the default namespace denoted by an empty prefix.
+func prefixesOfRawTag(rawTag string) (used, declared []string) {
+	rawTag = strings.TrimPrefix(strings.TrimPrefix(rawTag, "<"), "/")
+	rawTag = strings.TrimSuffix(strings.TrimSuffix(rawTag, ">"), "/")
+	// end index of the tag name
+	nameEnd := strings.IndexFunc(rawTag, unicode.IsSpace)
+	if nameEnd == -1 {
+		nameEnd = len(rawTag)
+	}
+	if idx := strings.Index(rawTag[:nameEnd], ":"); idx != -1 {
+		used = append(used, rawTag[:idx])
+	} else {
+		used = append(used, "")
+	}
+
+	rest := rawTag[nameEnd:]
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		eqIdx := strings.Index(rest, "=")
+		if eqIdx == -1 {
+			return used, declared
+		}
+		attrName := strings.TrimSpace(rest[:eqIdx])
+		switch {
+		case attrName == "xmlns":
+			declared = append(declared, "")
+		case strings.HasPrefix(attrName, "xmlns:"):
+			declared = append(declared, strings.TrimPrefix(attrName, "xmlns:"))
+		case strings.Contains(attrName, ":"):
+			// unprefixed attributes doesn't belong to any namespace.
+			prefix := attrName[:strings.Index(attrName, ":")]
+			if prefix != "xml" {
+				used = append(used, prefix)
+			}
+		}
+
+		// skipping the quoted value of the attribute.
+		rest = strings.TrimLeftFunc(rest[eqIdx+1:], unicode.IsSpace)
+		if len(rest) == 0 {
+			return used, declared
+		}
+		closingQuoteIdx := strings.IndexByte(rest[1:], rest[0])
+		if closingQuoteIdx == -1 {
+			return used, declared
+		}
+		rest = rest[closingQuoteIdx+2:]
+	}
+}
+
+// entities predefined by xml. They can be used without any declaration.
+var predefinedEntities = map[string]string{
+	"amp":  "&",
+	"lt":   "<",
+	"gt":   ">",
+	"quot": `"`,
+	"apos": "'",
+}
+
+// maximum length of a value after expanding the entity references.
+// protects against entities expanding exponentially like the billion laughs.
+const maxExpandedLength = 1 << 24
+
+// replaces the character references and the entity references with the
+// characters they refer to. entities maps the names of the declared entities
+// to their replacement text. For example:
+//
+//	&amp;lt; -> &lt;
+//	&#169;   -> ©
+//	&#xA9;   -> ©
+//	&spdx;   -> http://spdx.org/rdf/terms# if declared by the DOCTYPE as
+//	            <!ENTITY spdx "http://spdx.org/rdf/terms#">
+func decodeReferences(value string, entities map[string]string) (string, error) {
+	return expandReferences(value, entities, nil)
+}
+
+// same as decodeReferences. expanding is the list of entities being expanded.
+// An entity referring to itself directly or indirectly is an error.
+func expandReferences(value string, entities map[string]string, expanding []string) (string, error) {
+	if !strings.Contains(value, "&") {
+		return value, nil
+	}
+	var output strings.Builder
+	for {
+		ampIdx := strings.IndexByte(value, '&')
+		if ampIdx == -1 {
+			output.WriteString(value)
+			return output.String(), nil
+		}
+		output.WriteString(value[:ampIdx])
+		value = value[ampIdx+1:]
+
+		semicolonIdx := strings.IndexByte(value, ';')
+		if semicolonIdx == -1 {
+			return "", fmt.Errorf("found & without a reference ending in ;")
+		}
+		name := value[:semicolonIdx]
+		value = value[semicolonIdx+1:]
+
+		if strings.HasPrefix(name, "#") {
+			r, err := decodeCharReference(name[1:])
+			if err != nil {
+				return "", err
+			}
+			output.WriteRune(r)
+			continue
+		}
+		if replacement, exists := predefinedEntities[name]; exists {
+			output.WriteString(replacement)
+			continue
+		}
+		replacement, exists := entities[name]
+		if !exists {
+			return "", fmt.Errorf("undefined entity &%s;", name)
+		}
+		if contains(expanding, name) {
+			return "", fmt.Errorf("entity &%s; refers to itself", name)
+		}
+		expanded, err := expandReferences(replacement, entities, append(expanding, name))
+		if err != nil {
+			return "", err
+		}
+		output.WriteString(expanded)
+		if output.Len() > maxExpandedLength {
+			return "", fmt.Errorf("value exceeds %d bytes after expanding the entities", maxExpandedLength)
+		}
+	}
+}
+
+// returns the character of a character reference without the "&#" and ";".
+// the reference is a decimal number or a hexadecimal number prefixed by x.
+func decodeCharReference(reference string) (rune, error) {
+	base := 10
+	digits := reference
+	if strings.HasPrefix(reference, "x") {
+		base = 16
+		digits = reference[1:]
+	}
+	codePoint, err := strconv.ParseUint(digits, base, 32)
+	if err != nil || digits == "" || strings.ContainsAny(digits, "+-") {
+		return 0, fmt.Errorf("invalid character reference &#%s;", reference)
+	}
+	r := rune(codePoint)
+	if !isXMLChar(r) {
+		return 0, fmt.Errorf("character reference &#%s; doesn't refer to a valid xml character", reference)
+	}
+	return r, nil
+}
+
+// returns true if the rune is allowed in a xml document.
+// https://www.w3.org/TR/xml/#charsets
+func isXMLChar(r rune) bool {
+	return r == '\t' || r == '\n' || r == '\r' ||
+		(r >= 0x20 && r <= 0xD7FF) ||
+		(r >= 0xE000 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0x10FFFF)
+}
+
+// returns the value of an attribute as seen by the application.
+// whitespace characters are replaced with a space before the references are
+// decoded. https://www.w3.org/TR/xml/#AVNormalize
+func normalizeAttributeValue(value string, entities map[string]string) (string, error) {
+	value = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ", "\t", " ").Replace(value)
+	return decodeReferences(value, entities)
+}
+
+// returns the general entities declared in the internal subset of a DOCTYPE.
+// The internal subset is the part of the DOCTYPE enclosed in square brackets:
+//
+//	<!DOCTYPE rdf:RDF [
+//	    <!ENTITY spdx "http://spdx.org/rdf/terms#">
+//	]>
+//
+// Parameter entities and external entities are not supported and are ignored.
+// Other declarations, comments and processing instructions are skipped.
+func parseInternalSubset(subset string) (entities map[string]string, err error) {
+	entities = map[string]string{}
+	for {
+		subset = strings.TrimLeftFunc(subset, unicode.IsSpace)
+		var declaration string
+		switch {
+		case subset == "":
+			return entities, nil
+		case strings.HasPrefix(subset, "<!--"):
+			subset, err = skipPast(subset, "-->")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(subset, "<?"):
+			subset, err = skipPast(subset, "?>")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(subset, "%"):
+			// reference to a parameter entity.
+			subset, err = skipPast(subset, ";")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case strings.HasPrefix(subset, "<!"):
+			// a markup declaration ends at the first '>' outside the quotes.
+			end := indexOutsideQuotes(subset, '>')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated declaration in DOCTYPE: %v", subset)
+			}
+			declaration, subset = subset[:end], subset[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected chars in DOCTYPE: %v", subset)
+		}
+
+		if !strings.HasPrefix(declaration, "<!ENTITY") {
+			// element, attribute-list and notation declarations.
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(declaration, "<!ENTITY"))
+		if len(fields) < 2 || fields[0] == "%" {
+			// parameter entity declaration.
+			continue
+		}
+		name := fields[0]
+		value := strings.TrimSpace(strings.TrimPrefix(strings.TrimLeftFunc(strings.TrimPrefix(declaration, "<!ENTITY"), unicode.IsSpace), name))
+		if len(value) < 2 || (value[0] != '"' && value[0] != '\'') {
+			// external entity given by SYSTEM or PUBLIC identifiers.
+			continue
+		}
+		closingQuoteIdx := strings.IndexByte(value[1:], value[0])
+		if closingQuoteIdx == -1 {
+			return nil, fmt.Errorf("expected a closing quote in the declaration of the entity %v", name)
+		}
+		if _, exists := entities[name]; !exists {
+			// first declaration of an entity is binding.
+			entities[name] = value[1 : closingQuoteIdx+1]
+		}
+	}
+}
+
+// returns the part of the text after the first occurrence of the delimiter.
+func skipPast(text, delimiter string) (string, error) {
+	idx := strings.Index(text, delimiter)
+	if idx == -1 {
+		return text, fmt.Errorf("expected %v", delimiter)
+	}
+	return text[idx+len(delimiter):], nil
+}
+
+// returns the index of the first occurrence of the target byte which is not
+// enclosed in single or double quotes. returns -1 if there is no such byte.
+func indexOutsideQuotes(text string, target byte) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		switch {
+		case quote != 0:
+			if text[i] == quote {
+				quote = 0
+			}
+		case text[i] == '"' || text[i] == '\'':
+			quote = text[i]
+		case text[i] == target:
+			return i
+		}
+	}
+	return -1
+}
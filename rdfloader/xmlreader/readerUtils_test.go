@@ -3,7 +3,9 @@ package rdfloader
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -12,6 +14,16 @@ func xmlreaderFromString(fileContent string) XMLReader {
 	return XMLReaderFromFileObject(bufio.NewReader(io.Reader(bytes.NewReader([]byte(fileContent)))))
 }
 
+// returns a reader of every implementation of the Reader for the same content.
+func readersFromString(fileContent string) map[string]Reader {
+	xmlReader := xmlreaderFromString(fileContent)
+	tokenReader := TokenReaderFromFileObject(bufio.NewReader(strings.NewReader(fileContent)))
+	return map[string]Reader{
+		"XMLReader":   &xmlReader,
+		"TokenReader": &tokenReader,
+	}
+}
+
 func TestXMLReader_ignoreWhiteSpace(t *testing.T) {
 	// string starting with 4 blank characters.
 	fileContent1 := "\n \r\tsample string"
@@ -145,6 +157,16 @@ func TestXMLReader_readTill(t *testing.T) {
 	if r != ' ' {
 		t.Errorf("Expected %v, Found %v", ' ', r)
 	}
+
+	// multi-byte utf-8 characters must be read as they are.
+	xmlReader = xmlreaderFromString("Copyright © 2020 Jürgen<")
+	op, err = xmlReader.readTill(1 << '<')
+	if err != nil {
+		t.Error(err)
+	}
+	if string(op) != "Copyright © 2020 Jürgen" {
+		t.Errorf("Expected %v, Found: %v", "Copyright © 2020 Jürgen", string(op))
+	}
 }
 
 func TestXMLReader_readTillString(t *testing.T) {
@@ -190,3 +212,99 @@ func TestXMLReader_readTillString(t *testing.T) {
 		t.Errorf("faulty parsing. expected: %s, found: %s", expected, string(output))
 	}
 }
+
+func Test_decodeReferences(t *testing.T) {
+	// TestCase 1: predefined entities and character references are decoded.
+	output, err := decodeReferences("Copyright &#169; &#xA9; &#x00a9; 2020 Smith &amp; Sons &lt;smith@example.com&gt; &quot;&apos;", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := `Copyright © © © 2020 Smith & Sons <smith@example.com> "'`
+	if output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+
+	// TestCase 2: references are decoded only once.
+	output, _ = decodeReferences("&amp;lt;", nil)
+	if output != "&lt;" {
+		t.Errorf("expected &lt;, found %v", output)
+	}
+
+	// TestCase 3: invalid references must raise an error.
+	for _, value := range []string{"a & b", "&unknown;", "&#;", "&#xZZ;", "&#0;", "&#-1;", "&#x110000;"} {
+		if _, err = decodeReferences(value, nil); err == nil {
+			t.Errorf("expected an error decoding %v", value)
+		}
+	}
+
+	// TestCase 4: declared entities are expanded recursively.
+	entities := map[string]string{"spdx": "http://spdx.org/rdf/terms#", "file": "&spdx;File"}
+	output, err = decodeReferences("&file; &amp; &spdx;", entities)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expected = "http://spdx.org/rdf/terms#File & http://spdx.org/rdf/terms#"; output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+
+	// TestCase 5: entities referring to themselves must raise an error.
+	entities = map[string]string{"a": "&b;", "b": "x&a;"}
+	if _, err = decodeReferences("&a;", entities); err == nil {
+		t.Errorf("expected an error decoding a recursive entity")
+	}
+
+	// TestCase 6: entities expanding exponentially must raise an error.
+	entities = map[string]string{"lol0": "lololololololololololololololol"}
+	for i := 1; i < 10; i++ {
+		previous := fmt.Sprintf("&lol%d;", i-1)
+		entities[fmt.Sprintf("lol%d", i)] = strings.Repeat(previous, 10)
+	}
+	if _, err = decodeReferences("&lol9;", entities); err == nil {
+		t.Errorf("expected an error decoding an exponentially expanding entity")
+	}
+}
+
+func Test_parseInternalSubset(t *testing.T) {
+	// TestCase 1: general entities are returned and other declarations are ignored.
+	subset := `
+		<!-- entities of the spdx document -->
+		<!ENTITY spdx "http://spdx.org/rdf/terms#">
+		<!ENTITY rdfs 'http://www.w3.org/2000/01/rdf-schema#'>
+		<!ENTITY spdx "http://example.com/ignored#">
+		<!ENTITY % param "ignored">
+		%param;
+		<!ENTITY logo SYSTEM "logo.gif">
+		<!ELEMENT spdx:File (#PCDATA)>
+		<!ATTLIST spdx:File name CDATA "a>b">
+		<?pi data?>
+	`
+	entities, err := parseInternalSubset(subset)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"spdx": "http://spdx.org/rdf/terms#",
+		"rdfs": "http://www.w3.org/2000/01/rdf-schema#",
+	}
+	if !reflect.DeepEqual(entities, expected) {
+		t.Errorf("expected %v, found %v", expected, entities)
+	}
+
+	// TestCase 2: unterminated declarations must raise an error.
+	for _, subset := range []string{`<!ENTITY spdx "http://spdx.org/rdf/terms#`, `<!ENTITY spdx "a>`, "stray chars"} {
+		if _, err = parseInternalSubset(subset); err == nil {
+			t.Errorf("expected an error parsing %v", subset)
+		}
+	}
+}
+
+func Test_normalizeAttributeValue(t *testing.T) {
+	// whitespaces are replaced by a space but the character references are retained.
+	output, err := normalizeAttributeValue("line1\r\nline2\tA&#10;B", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if output != "line1 line2 A\nB" {
+		t.Errorf("expected %q, found %q", "line1 line2 A\nB", output)
+	}
+}
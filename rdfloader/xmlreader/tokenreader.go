@@ -0,0 +1,419 @@
+package rdfloader
+
+/**
+ * This module provides a reader built on the tokens of encoding/xml.
+ * It reads a document into the same tree of blocks as the XMLReader.
+ */
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// records the bytes read by the decoder till they are taken.
+// raw text of the tokens is needed to read the CDATA sections, xml literals
+// and the references in the values exactly like the XMLReader.
+type recordingReader struct {
+	reader   *bufio.Reader
+	offset   int64 // offset of the first recorded byte in the document.
+	recorded []byte
+}
+
+func (r *recordingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	r.recorded = append(r.recorded, p[:n]...)
+	return n, err
+}
+
+func (r *recordingReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.recorded = append(r.recorded, b)
+	}
+	return b, err
+}
+
+// returns the recorded text between the start and end offsets.
+// The text before the end offset is no longer recorded.
+func (r *recordingReader) take(start, end int64) string {
+	raw := string(r.recorded[start-r.offset : end-r.offset])
+	r.recorded = r.recorded[end-r.offset:]
+	r.offset = end
+	return raw
+}
+
+type TokenReader struct {
+	decoder *xml.Decoder
+	input   *recordingReader
+	fileObj *os.File
+	// namespace declarations (xmlns attributes) of the tags enclosing the
+	// block being read. Last element belongs to the innermost tag.
+	namespaces [][]Attribute
+	// general entities declared in the internal subset of the DOCTYPE.
+	// maps the name of the entity to its replacement text.
+	entities map[string]string
+	counter  positionCounter
+	// position of the first char of the last token.
+	tokenStart Position
+}
+
+// returns the next token of the document along with its raw text.
+func (tokenReader *TokenReader) nextToken() (token xml.Token, raw string, err error) {
+	start := tokenReader.decoder.InputOffset()
+	token, err = tokenReader.decoder.RawToken()
+	if err != nil {
+		return nil, "", err
+	}
+	raw = tokenReader.input.take(start, tokenReader.decoder.InputOffset())
+	tokenReader.tokenStart = tokenReader.counter.position()
+	tokenReader.counter.advance([]byte(raw))
+	return token, raw, nil
+}
+
+// returns the raw values of the attributes of a raw opening tag in the order
+// of their occurrence. Quotes enclosing the values are removed.
+func rawAttributeValues(rawTag string) (values []string) {
+	rest := rawTag
+	for {
+		// neither the tag name nor the attribute names can have a '=' char.
+		eqIdx := strings.Index(rest, "=")
+		if eqIdx == -1 {
+			return values
+		}
+		rest = strings.TrimLeftFunc(rest[eqIdx+1:], unicode.IsSpace)
+		if len(rest) == 0 {
+			return values
+		}
+		closingQuoteIdx := strings.IndexByte(rest[1:], rest[0])
+		if closingQuoteIdx == -1 {
+			return values
+		}
+		values = append(values, rest[1:closingQuoteIdx+1])
+		rest = rest[closingQuoteIdx+2:]
+	}
+}
+
+// converts the start element token into a tag.
+// attribute values are read from the raw text of the token to normalize
+// them like the XMLReader.
+func (tokenReader *TokenReader) tagFromToken(token xml.StartElement, raw string) (tag Tag, err error) {
+	tag.SchemaName = token.Name.Space
+	tag.Name = token.Name.Local
+	tag.Position = tokenReader.tokenStart
+	values := rawAttributeValues(raw)
+	if len(values) != len(token.Attr) {
+		return tag, fmt.Errorf("malformed attributes in the tag %v", raw)
+	}
+	for i, attr := range token.Attr {
+		value, err := normalizeAttributeValue(values[i], tokenReader.entities)
+		if err != nil {
+			return tag, err
+		}
+		tag.Attrs = append(tag.Attrs, Attribute{
+			Name:       attr.Name.Local,
+			SchemaName: attr.Name.Space,
+			Value:      value,
+		})
+	}
+	return tag, nil
+}
+
+// reads the general entities declared by the internal subset of the DOCTYPE.
+func (tokenReader *TokenReader) readDoctype(raw string) error {
+	if !strings.HasPrefix(raw, "<!DOCTYPE") {
+		return fmt.Errorf("unexpected declaration %v", raw)
+	}
+	subsetStart := indexOutsideQuotes(raw, '[')
+	if subsetStart == -1 {
+		// doctype without an internal subset.
+		return nil
+	}
+	subsetEnd := strings.LastIndexByte(raw, ']')
+	if subsetEnd < subsetStart {
+		return fmt.Errorf("expected ] in DOCTYPE: %v", raw)
+	}
+	entities, err := parseInternalSubset(raw[subsetStart+1 : subsetEnd])
+	if err != nil {
+		return err
+	}
+	tokenReader.entities = entities
+	// decoder must know the entities to accept the references to them.
+	tokenReader.decoder.Entity = entities
+	return nil
+}
+
+// reads the content of the block with the given opening tag as a xml literal
+// till the closing tag of the block.
+func (tokenReader *TokenReader) readXMLLiteral(openingTag Tag) (literal string, err error) {
+	var content strings.Builder
+	depth := 0
+	for {
+		token, raw, err := tokenReader.nextToken()
+		if err != nil {
+			return literal, fmt.Errorf("%v reading xml literal", err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				err = matchingTags(openingTag, Tag{SchemaName: token.Name.Space, Name: token.Name.Local, Position: tokenReader.tokenStart})
+				if err != nil {
+					return literal, err
+				}
+				// namespaces used by the literal are declared by the XMLReader
+				// in the same way. The literal ends at the closing tag.
+				literalReader := XMLReader{
+					fileReader: bufio.NewReader(strings.NewReader(content.String() + "</")),
+					namespaces: tokenReader.namespaces,
+				}
+				return literalReader.readXMLLiteral()
+			}
+			depth--
+		}
+		content.WriteString(raw)
+	}
+}
+
+// reads the block of the given start element till its closing tag.
+func (tokenReader *TokenReader) readBlock(start xml.StartElement, raw string) (block Block, err error) {
+	block.OpeningTag, err = tokenReader.tagFromToken(start, raw)
+	if err != nil {
+		return block, err
+	}
+
+	// namespaces declared by the tag are in scope till its closing tag.
+	tokenReader.namespaces = append(tokenReader.namespaces, namespaceDeclarations(block.OpeningTag))
+	defer func() {
+		tokenReader.namespaces = tokenReader.namespaces[:len(tokenReader.namespaces)-1]
+	}()
+
+	if isXMLLiteral(block.OpeningTag, inScopeNamespaces(tokenReader.namespaces)) {
+		// content of the tag is a xml literal and must be read as it is.
+		block.Value, err = tokenReader.readXMLLiteral(block.OpeningTag)
+		return block, err
+	}
+
+	err = tokenReader.readContent(&block, func(childBlock Block) error {
+		block.Children = append(block.Children, &childBlock)
+		return nil
+	})
+	return block, err
+}
+
+// reads the content of the block till its closing tag. Every child block is
+// given to the handleChild function as soon as it is read. The block has the
+// value of the content, if any.
+func (tokenReader *TokenReader) readContent(block *Block, handleChild func(childBlock Block) error) error {
+	// value of the block is its text joined with the content of the CDATA
+	// sections in it. comments and processing instructions are excluded.
+	var value string
+	hasCDATA, hasChildren := false, false
+	for {
+		token, raw, err := tokenReader.nextToken()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if hasCDATA || strings.Trim(value, " \t\r\n") != "" {
+				return mixedContentError(block.OpeningTag, value)
+			}
+			childBlock, err := tokenReader.readBlock(token, raw)
+			if err != nil {
+				return err
+			}
+			hasChildren = true
+			if err = handleChild(childBlock); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			err = matchingTags(block.OpeningTag, Tag{SchemaName: token.Name.Space, Name: token.Name.Local, Position: tokenReader.tokenStart})
+			if err != nil {
+				return err
+			}
+			if !hasChildren {
+				block.Value = value
+			}
+			return nil
+		case xml.CharData:
+			isCDATA := strings.HasPrefix(raw, "<![CDATA[")
+			if hasChildren && (isCDATA || strings.Trim(raw, " \t\r\n") != "") {
+				return mixedContentError(block.OpeningTag, raw)
+			}
+			if isCDATA {
+				// content of a cdata section is never parsed.
+				value += string(token)
+				hasCDATA = true
+				continue
+			}
+			// references like &amp; and &#169; are replaced by the characters.
+			text, err := decodeReferences(raw, tokenReader.entities)
+			if err != nil {
+				return err
+			}
+			value += text
+		case xml.Directive:
+			return fmt.Errorf("unexpected declaration %v in %v:%v", raw, block.OpeningTag.SchemaName, block.OpeningTag.Name)
+		}
+		// comments and processing instructions are skipped.
+	}
+}
+
+// reads the prolog of the document till the start element of the root tag.
+func (tokenReader *TokenReader) readProlog() (root xml.StartElement, raw string, err error) {
+	doctypeFound := false
+	for {
+		token, raw, err := tokenReader.nextToken()
+		if err != nil {
+			return root, raw, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			return token, raw, nil
+		case xml.Directive:
+			if doctypeFound {
+				return root, raw, fmt.Errorf("found more than one DOCTYPE")
+			}
+			doctypeFound = true
+			if err = tokenReader.readDoctype(raw); err != nil {
+				return root, raw, err
+			}
+		case xml.CharData, xml.EndElement:
+			if strings.Trim(raw, " \t\r\n") != "" {
+				return root, raw, fmt.Errorf("found extra chars before tag start: %v", raw)
+			}
+		}
+		// comments and processing instructions are skipped.
+	}
+}
+
+// Read reads the document into a tree of blocks. Errors in the document are
+// returned as a *ParseError.
+func (tokenReader *TokenReader) Read() (rootBlock Block, err error) {
+	rootBlock, err = tokenReader.read()
+	return rootBlock, errorAt(tokenReader.counter.position(), err)
+}
+
+func (tokenReader *TokenReader) read() (rootBlock Block, err error) {
+	defer tokenReader.CloseFileObj()
+	root, raw, err := tokenReader.readProlog()
+	if err != nil {
+		return rootBlock, err
+	}
+	rootBlock, err = tokenReader.readBlock(root, raw)
+	if err != nil {
+		return rootBlock, err
+	}
+	return rootBlock, tokenReader.readEpilog()
+}
+
+// ReadEach reads the document like Read but without holding the children of
+// the root block in memory. handleRoot is called with the root block having
+// only the opening tag. Then, handleChild is called with every child of the
+// root block as soon as the child is read.
+// Errors returned by the handlers are returned as they are.
+func (tokenReader *TokenReader) ReadEach(handleRoot func(rootBlock Block) error, handleChild func(childBlock Block) error) error {
+	handlers := eachHandlers{handleRoot: handleRoot, handleChild: handleChild}
+	err := tokenReader.readEach(handlers.root, handlers.child)
+	return handlers.errorAt(tokenReader.counter.position(), err)
+}
+
+func (tokenReader *TokenReader) readEach(handleRoot func(rootBlock Block) error, handleChild func(childBlock Block) error) error {
+	defer tokenReader.CloseFileObj()
+	root, raw, err := tokenReader.readProlog()
+	if err != nil {
+		return err
+	}
+	rootBlock := Block{}
+	rootBlock.OpeningTag, err = tokenReader.tagFromToken(root, raw)
+	if err != nil {
+		return err
+	}
+	if err = handleRoot(rootBlock); err != nil {
+		return err
+	}
+	// namespaces declared by the root tag are in scope till its closing tag.
+	tokenReader.namespaces = append(tokenReader.namespaces, namespaceDeclarations(rootBlock.OpeningTag))
+	err = tokenReader.readContent(&rootBlock, handleChild)
+	tokenReader.namespaces = tokenReader.namespaces[:len(tokenReader.namespaces)-1]
+	if err != nil {
+		return err
+	}
+	return tokenReader.readEpilog()
+}
+
+// after reading the root block, there shouldn't be any other tags or
+// characters except comments and processing instructions.
+func (tokenReader *TokenReader) readEpilog() error {
+	for {
+		token, raw, err := tokenReader.nextToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch token.(type) {
+		case xml.Comment, xml.ProcInst:
+			continue
+		case xml.CharData:
+			if strings.Trim(raw, " \t\r\n") == "" {
+				continue
+			}
+		}
+		return fmt.Errorf("unexpected chars after reading root block. Found: %v", raw)
+	}
+}
+
+// content of the fileObject must be UTF-8. Documents in other encodings are
+// read by TokenReaderFromFileObjectWithCharsetReader.
+func TokenReaderFromFileObject(fileObject *bufio.Reader) TokenReader {
+	// user will be responsible for closing the file.
+	input := &recordingReader{reader: fileObject}
+	return TokenReader{decoder: xml.NewDecoder(input), input: input}
+}
+
+// TokenReaderFromFileObjectWithCharsetReader converts the content of the
+// fileObject to UTF-8 using NewUTF8Reader before reading it. charsetReader
+// converts the encodings not supported by NewUTF8Reader and may be nil.
+func TokenReaderFromFileObjectWithCharsetReader(fileObject io.Reader, charsetReader CharsetReader) (TokenReader, error) {
+	utf8Reader, err := NewUTF8Reader(fileObject, charsetReader)
+	if err != nil {
+		return TokenReader{}, err
+	}
+	tokenReader := TokenReaderFromFileObject(utf8Reader)
+	// content is converted to UTF-8 already. The encoding declaration of the
+	// document is no longer relevant.
+	tokenReader.decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	return tokenReader, nil
+}
+
+func TokenReaderFromFilePath(filePath string) (tokenReader TokenReader, err error) {
+	fileObj, err := os.Open(filePath)
+	if err != nil {
+		return tokenReader, err
+	}
+
+	// content of the file is converted to UTF-8 before reading it.
+	tokenReader, err = TokenReaderFromFileObjectWithCharsetReader(fileObj, nil)
+	if err != nil {
+		fileObj.Close()
+		return tokenReader, err
+	}
+	tokenReader.fileObj = fileObj
+	return tokenReader, nil
+}
+
+func (tokenReader *TokenReader) CloseFileObj() {
+	if tokenReader.fileObj != nil {
+		tokenReader.fileObj.Close()
+	}
+}
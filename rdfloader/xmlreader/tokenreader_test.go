@@ -0,0 +1,51 @@
+package rdfloader
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_rawAttributeValues(t *testing.T) {
+	rawTag := `<spdx:File rdf:about = "#a>b" spdx:name='it&apos;s "quoted"' spdx:empty="">`
+	expected := []string{"#a>b", `it&apos;s "quoted"`, ""}
+	if values := rawAttributeValues(rawTag); !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v, found %v", expected, values)
+	}
+}
+
+func TestTokenReader_Read(t *testing.T) {
+	// both the readers must read a document into the same tree of blocks.
+	testString := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE rdf:RDF [<!ENTITY spdx "http://spdx.org/rdf/terms#">]>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="&spdx;">
+	<spdx:Package rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1" spdx:name="line1
+	line2&#10;line3">
+		<spdx:copyrightText>Copyright &#169; 2020 Jürgen &amp; Sons</spdx:copyrightText>
+		<spdx:supplier>
+			<![CDATA[Organization: Smith & Sons <legal@smith.example>]]>
+		</spdx:supplier>
+		<spdx:licenseText rdf:parseType="Literal"><p xmlns="http://www.w3.org/1999/xhtml">MIT <b>&amp;</b></p></spdx:licenseText>
+		<spdx:checksum rdf:parseType="Resource">
+			<spdx:algorithm rdf:resource="&spdx;checksumAlgorithm_sha1"/>
+		</spdx:checksum>
+		<spdx:comment>  </spdx:comment>
+	</spdx:Package>
+</rdf:RDF>`
+	xmlReader := xmlreaderFromString(testString)
+	expected, err := xmlReader.Read()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	tokenReader := TokenReaderFromFileObject(bufio.NewReader(strings.NewReader(testString)))
+	output, err := tokenReader.Read()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("mismatching blocks. \nExpected: %+v. \nFound: %+v", expected, output)
+	}
+}
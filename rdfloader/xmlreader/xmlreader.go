@@ -8,12 +8,18 @@ package rdfloader
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 )
 
+// namespace of the rdf:parseType attribute.
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
 // reads a:b into a Pair Object.
 func (xmlReader *XMLReader) readColonPair(delim uint64) (pair Pair, colonFound bool, err error) {
 	// file pointer must point to the start of the attribute.
@@ -73,19 +79,19 @@ func (xmlReader *XMLReader) readAttribute() (attr Attribute, err error) {
 		err = errors.New("assignment operator must be followed by an attribute enclosed within quotes")
 	}
 
-	// read till next quote or a blank character.
-	word, err := xmlReader.readTill(WHITESPACE | 1<<uint(firstQuote))
+	// read till the next quote. attribute values can have blank characters.
+	word, err := xmlReader.readTill(1 << uint(firstQuote))
 	if err != nil {
 		return attr, err
 	}
 
 	secondQuote, _ := xmlReader.readARune()
 	if firstQuote != secondQuote {
-		return attr, errors.New("unexpected blank char. expected a closing quote")
+		return attr, errors.New("expected a closing quote")
 	}
 
-	attr.Value = string(word)
-	return attr, nil
+	attr.Value, err = normalizeAttributeValue(string(word), xmlReader.entities)
+	return attr, err
 }
 
 func (xmlReader *XMLReader) readCDATA() (cdata string, err error) {
@@ -112,7 +118,272 @@ func (xmlReader *XMLReader) readCDATA() (cdata string, err error) {
 	// move file pointer by 3 to ignore the ]]> chars.
 	xmlReader.readNBytes(len(CDATA_CLOSING))
 
-	return CDATA_OPENING + string(data) + CDATA_CLOSING, nil
+	// content of a cdata section is never parsed.
+	return string(data), nil
+}
+
+// skips a comment <!-- ... --> or a processing instruction <? ... ?> if the
+// file pointer points to one. skipped is false if it points to anything else.
+func (xmlReader *XMLReader) skipCommentOrPI() (skipped bool, err error) {
+	for _, delimiters := range [][2]string{{"<!--", "-->"}, {"<?", "?>"}} {
+		nextBytes, err := xmlReader.peekNBytes(len(delimiters[0]))
+		if err != nil || string(nextBytes) != delimiters[0] {
+			continue
+		}
+		xmlReader.readNBytes(len(delimiters[0]))
+		_, err = xmlReader.readTillString(delimiters[1])
+		if err != nil {
+			return false, fmt.Errorf("%v reading %v", err, delimiters[1])
+		}
+		xmlReader.readNBytes(len(delimiters[1]))
+		return true, nil
+	}
+	return false, nil
+}
+
+// reads the text till the next tag. comments and processing instructions
+// within the text are skipped.
+func (xmlReader *XMLReader) readText() (string, error) {
+	var text []rune
+	for {
+		word, err := xmlReader.readTill(1 << '<')
+		if err != nil {
+			return "", err
+		}
+		text = append(text, word...)
+		// text continues after a comment or a processing instruction.
+		skipped, err := xmlReader.skipCommentOrPI()
+		if err != nil || !skipped {
+			return string(text), err
+		}
+	}
+}
+
+// skips the whitespaces, comments and processing instructions till any other
+// char is found. returns an EOF error if the end of the file is reached.
+func (xmlReader *XMLReader) skipMisc() error {
+	for {
+		_, err := xmlReader.ignoreWhiteSpace()
+		if err != nil {
+			return err
+		}
+		skipped, err := xmlReader.skipCommentOrPI()
+		if err != nil || !skipped {
+			return err
+		}
+	}
+}
+
+// reads the document type declaration and the general entities declared by
+// its internal subset. For example:
+//
+//	<!DOCTYPE rdf:RDF [
+//	    <!ENTITY spdx "http://spdx.org/rdf/terms#">
+//	]>
+//
+// the file pointer should point to the < char of <!DOCTYPE.
+func (xmlReader *XMLReader) readDoctype() error {
+	DOCTYPE_OPENING := "<!DOCTYPE"
+	nBytes, err := xmlReader.readNBytes(len(DOCTYPE_OPENING))
+	if err != nil {
+		return err
+	}
+	if string(nBytes) != DOCTYPE_OPENING {
+		return fmt.Errorf("not a valid doctype. expected: %s, found: %s", DOCTYPE_OPENING, string(nBytes))
+	}
+
+	var subset []rune
+	var quote rune
+	inSubset := false
+	for {
+		if inSubset && quote == 0 {
+			// comments of the internal subset may have quotes and brackets.
+			skipped, err := xmlReader.skipCommentOrPI()
+			if err != nil {
+				return err
+			}
+			if skipped {
+				continue
+			}
+		}
+		r, err := xmlReader.readARune()
+		if err != nil {
+			return fmt.Errorf("%v reading DOCTYPE", err)
+		}
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case !inSubset && r == '[':
+			inSubset = true
+			continue
+		case inSubset && r == ']':
+			inSubset = false
+			continue
+		case !inSubset && r == '>':
+			xmlReader.entities, err = parseInternalSubset(string(subset))
+			return err
+		}
+		if inSubset {
+			subset = append(subset, r)
+		}
+	}
+}
+
+// reads the prolog of the document. That is, the xml declaration, doctype,
+// comments and processing instructions before the root tag.
+func (xmlReader *XMLReader) readProlog() error {
+	doctypeFound := false
+	for {
+		err := xmlReader.skipMisc()
+		if err != nil {
+			return err
+		}
+		nextBytes, _ := xmlReader.peekNBytes(len("<!DOCTYPE"))
+		if string(nextBytes) != "<!DOCTYPE" {
+			return nil
+		}
+		if doctypeFound {
+			return errors.New("found more than one DOCTYPE")
+		}
+		doctypeFound = true
+		if err = xmlReader.readDoctype(); err != nil {
+			return err
+		}
+	}
+}
+
+// returns true if the content of the block with the given opening tag must
+// be read as a xml literal instead of children blocks. It is the case when
+// the tag has a rdf:parseType attribute other than "Resource" and "Collection".
+// namespaces are the namespaces in scope including the ones declared by the tag.
+func isXMLLiteral(tag Tag, namespaces map[string]string) bool {
+	for _, attr := range tag.Attrs {
+		if attr.Name != "parseType" || attr.SchemaName == "" {
+			continue
+		}
+		namespace, declared := namespaces[attr.SchemaName]
+		if declared && strings.TrimSuffix(namespace, "#") != strings.TrimSuffix(rdfNamespace, "#") {
+			// parseType attribute of some other namespace.
+			continue
+		}
+		if !declared && attr.SchemaName != "rdf" {
+			continue
+		}
+		return attr.Value != "Resource" && attr.Value != "Collection"
+	}
+	return false
+}
+
+// reads a comment, cdata or processing instruction as it is till the delimiter.
+// the file pointer must point to the start of the section.
+func (xmlReader *XMLReader) readRawSection(delimiter string) ([]byte, error) {
+	section, err := xmlReader.readTillString(delimiter)
+	if err != nil {
+		return section, err
+	}
+	closing, err := xmlReader.readNBytes(len(delimiter))
+	return append(section, closing...), err
+}
+
+func (xmlReader *XMLReader) readXMLLiteral() (literal string, err error) {
+	// reads the content of the current block as it is till the closing tag
+	// of the block. The closing tag is not consumed.
+	// Namespaces declared outside the literal which are used by the tags of the
+	// literal are declared in the outermost tags of the literal. Which makes
+	// the literal a standalone xml. For example:
+	//   <rdf:RDF xmlns:rdf="..." xmlns:h="http://www.w3.org/1999/xhtml">
+	//     <rdf:Description>
+	//       <spdx:licenseText rdf:parseType="Literal"><h:b>text</h:b></spdx:licenseText>
+	// the literal is:
+	//   <h:b xmlns:h="http://www.w3.org/1999/xhtml">text</h:b>
+	namespaces := inScopeNamespaces(xmlReader.namespaces)
+	var output []byte
+	depth := 0
+
+	// states of the outermost tag which is currently open.
+	insertAt := -1 // index in output after the name of the tag.
+	used := map[string]bool{}
+	var declared []string
+
+	declareNamespaces := func() {
+		var prefixes []string
+		for prefix := range used {
+			if _, exists := namespaces[prefix]; exists && !contains(declared, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+		sort.Strings(prefixes)
+		var declarations string
+		for _, prefix := range prefixes {
+			if prefix == "" {
+				declarations += fmt.Sprintf(` xmlns="%s"`, namespaces[prefix])
+			} else {
+				declarations += fmt.Sprintf(` xmlns:%s="%s"`, prefix, namespaces[prefix])
+			}
+		}
+		output = append(output[:insertAt], append([]byte(declarations), output[insertAt:]...)...)
+	}
+
+	for {
+		text, err := xmlReader.readTillString("<")
+		if err != nil {
+			return literal, fmt.Errorf("%v reading xml literal", err)
+		}
+		output = append(output, text...)
+
+		next, _ := xmlReader.peekNBytes(len("<![CDATA["))
+		var section []byte
+		switch {
+		case bytes.HasPrefix(next, []byte("</")):
+			if depth == 0 {
+				// closing tag of the block containing the literal.
+				return string(output), nil
+			}
+			section, err = xmlReader.readRawTag()
+			depth--
+			output = append(output, section...)
+			if depth == 0 {
+				declareNamespaces()
+			}
+			continue
+		case bytes.HasPrefix(next, []byte("<!--")):
+			section, err = xmlReader.readRawSection("-->")
+		case bytes.HasPrefix(next, []byte("<![CDATA[")):
+			section, err = xmlReader.readRawSection("]]>")
+		case bytes.HasPrefix(next, []byte("<?")):
+			section, err = xmlReader.readRawSection("?>")
+		default:
+			// opening tag of an element in the literal.
+			section, err = xmlReader.readRawTag()
+			if err != nil {
+				return literal, fmt.Errorf("%v reading xml literal", err)
+			}
+			tagUsed, tagDeclared := prefixesOfRawTag(string(section))
+			if depth == 0 {
+				used = map[string]bool{}
+				declared = tagDeclared
+				insertAt = len(output) + 1 + strings.IndexAny(string(section[1:])+" ", " \t\r\n/>")
+			}
+			for _, prefix := range tagUsed {
+				used[prefix] = true
+			}
+			output = append(output, section...)
+			if !bytes.HasSuffix(section, []byte("/>")) {
+				depth++
+			} else if depth == 0 {
+				declareNamespaces()
+			}
+			continue
+		}
+		if err != nil {
+			return literal, fmt.Errorf("%v reading xml literal", err)
+		}
+		output = append(output, section...)
+	}
 }
 
 func (xmlReader *XMLReader) readOpeningTag() (tag Tag, isProlog, blockComplete bool, err error) {
@@ -154,6 +425,7 @@ func (xmlReader *XMLReader) readOpeningTag() (tag Tag, isProlog, blockComplete b
 	}
 
 	// next char is '<'.
+	tag.Position = xmlReader.counter.position()
 	xmlReader.readARune()
 	xmlReader.ignoreWhiteSpace() // there shouldn't be any spaces in a well-formed rdf/xml document.
 
@@ -297,6 +569,7 @@ func (xmlReader *XMLReader) readOpeningTag() (tag Tag, isProlog, blockComplete b
 
 func (xmlReader *XMLReader) readClosingTag() (closingTag Tag, err error) {
 	// expects white space to be stripped before the call to this function.
+	closingTag.Position = xmlReader.counter.position()
 	next2Bytes, err := xmlReader.readNBytes(2)
 	if err != nil {
 		return closingTag, err
@@ -345,91 +618,209 @@ func (xmlReader *XMLReader) readBlock() (block Block, err error) {
 		return block, err
 	}
 
-	xmlReader.ignoreWhiteSpace()
+	// namespaces declared by the tag are in scope till its closing tag.
+	xmlReader.namespaces = append(xmlReader.namespaces, namespaceDeclarations(openingTag))
+	defer func() {
+		xmlReader.namespaces = xmlReader.namespaces[:len(xmlReader.namespaces)-1]
+	}()
+
+	if isXMLLiteral(openingTag, inScopeNamespaces(xmlReader.namespaces)) {
+		// content of the tag is a xml literal and must be read as it is.
+		block.Value, err = xmlReader.readXMLLiteral()
+		if err != nil {
+			return block, err
+		}
+		return block, xmlReader.readMatchingClosingTag(openingTag)
+	}
 
 	// <schemaName:tagName [attributes] > is read till now.
-	nextRune, err := xmlReader.peekARune()
+	err = xmlReader.readContent(&block, func(childBlock Block) error {
+		block.Children = append(block.Children, &childBlock)
+		return nil
+	})
 	if err != nil {
-		return
+		return block, err
 	}
+	return block, xmlReader.readMatchingClosingTag(openingTag)
+}
 
-	if nextRune != '<' {
-		// the tag must be wrapping a string resource within it.
-		// tag is of type <schemaName:tagName> value </schemaName:tagName>
-		word, err := xmlReader.readTill(1 << '<') // according to the example, word=value.
+// reads the content of the block till its closing tag. The closing tag is not
+// consumed. Every child block is given to the handleChild function as soon as
+// it is read. The block has the value of the content, if any.
+func (xmlReader *XMLReader) readContent(block *Block, handleChild func(childBlock Block) error) error {
+	// value of the block is its text joined with the content of the CDATA
+	// sections in it.
+	var value string
+	hasCDATA := false
+	for {
+		// the text before the next tag is read as it is.
+		// references like &amp; and &#169; are replaced by the characters.
+		text, err := xmlReader.readText()
 		if err != nil {
-			return block, err
+			return err
 		}
-		block.Value = string(word)
-	} else {
+		text, err = decodeReferences(text, xmlReader.entities)
+		if err != nil {
+			return err
+		}
+		value += text
+
 		// expecting a new tag or closing tag of the currently read tag or CDATA.
 		nextTwoBytes, err := xmlReader.peekNBytes(2)
 		if err != nil {
-			return block, err
+			return err
 		}
 
-		if string(nextTwoBytes) == "<!" {
-			// cdata tag is found
-			cdataTag, err := xmlReader.readCDATA()
+		switch {
+		case string(nextTwoBytes) == "</":
+			// the tag must be wrapping a string resource within it.
+			// tag is of type <schemaName:tagName> value </schemaName:tagName>
+			// value is retained with all its whitespaces. That is, a value with
+			// only whitespaces is different from an empty value.
+			block.Value = value
+			return nil
+		case string(nextTwoBytes) == "<!":
+			// cdata tag is found. text continues after it.
+			cdata, err := xmlReader.readCDATA()
 			if err != nil {
-				return block, err
-			}
-			block.Value = cdataTag
-		} else {
-			// while we don't get a closing tag, read the children.
-			for string(nextTwoBytes) != "</" {
-				// a new tag is found.
-				childBlock, err := xmlReader.readBlock()
-				if err != nil {
-					return block, err
-				}
-
-				block.Children = append(block.Children, &childBlock)
-
-				xmlReader.ignoreWhiteSpace()
-				nextTwoBytes, err = xmlReader.peekNBytes(2)
-				if err != nil {
-					return block, err
-				}
+				return err
 			}
+			value += cdata
+			hasCDATA = true
+		case hasCDATA || strings.Trim(value, " \t\r\n") != "":
+			// whitespaces between the tags are insignificant but a text isn't.
+			return mixedContentError(block.OpeningTag, value)
+		default:
+			return xmlReader.readChildren(handleChild)
 		}
 	}
-	xmlReader.ignoreWhiteSpace()  // if any
-	closingTag, err := xmlReader.readClosingTag()
+}
+
+// reads the child blocks till the closing tag of the block. The closing tag
+// is not consumed.
+func (xmlReader *XMLReader) readChildren(handleChild func(childBlock Block) error) error {
+	nextTwoBytes, err := xmlReader.peekNBytes(2)
 	if err != nil {
-		return block, err
+		return err
 	}
-	if openingTag.Name != closingTag.Name || openingTag.SchemaName != closingTag.SchemaName {
-		// opening and closing tags are not same.
-		return block, fmt.Errorf("opening and closing tags doesn't match: opening tag; %v:%v, closing tag: %v:%v.", openingTag.SchemaName, openingTag.Name, closingTag.SchemaName, closingTag.Name)
+	// while we don't get a closing tag, read the children.
+	for string(nextTwoBytes) != "</" {
+		// a new tag is found.
+		childBlock, err := xmlReader.readBlock()
+		if err != nil {
+			return err
+		}
+		if err = handleChild(childBlock); err != nil {
+			return err
+		}
+
+		xmlReader.skipMisc()
+		nextTwoBytes, err = xmlReader.peekNBytes(2)
+		if err != nil {
+			return err
+		}
 	}
-	return block, err
+	return nil
 }
 
+// reads the closing tag and reports an error if it doesn't match the opening tag.
+func (xmlReader *XMLReader) readMatchingClosingTag(openingTag Tag) error {
+	closingTag, err := xmlReader.readClosingTag()
+	if err != nil {
+		return err
+	}
+	return matchingTags(openingTag, closingTag)
+}
+
+// Read reads the document into a tree of blocks. Errors in the document are
+// returned as a *ParseError.
 func (xmlReader *XMLReader) Read() (rootBlock Block, err error) {
+	rootBlock, err = xmlReader.read()
+	return rootBlock, errorAt(xmlReader.counter.position(), err)
+}
+
+func (xmlReader *XMLReader) read() (rootBlock Block, err error) {
+	err = xmlReader.readProlog()
+	if err != nil {
+		return rootBlock, err
+	}
 	rootBlock, err = xmlReader.readBlock()
 	if err != nil {
 		return rootBlock, err
 	}
-	if xmlReader.fileObj != nil {
-		xmlReader.fileObj.Close()
+	return rootBlock, xmlReader.readEpilog()
+}
+
+// ReadEach reads the document like Read but without holding the children of
+// the root block in memory. handleRoot is called with the root block having
+// only the opening tag. Then, handleChild is called with every child of the
+// root block as soon as the child is read.
+// Errors returned by the handlers are returned as they are.
+func (xmlReader *XMLReader) ReadEach(handleRoot func(rootBlock Block) error, handleChild func(childBlock Block) error) error {
+	handlers := eachHandlers{handleRoot: handleRoot, handleChild: handleChild}
+	err := xmlReader.readEach(handlers.root, handlers.child)
+	return handlers.errorAt(xmlReader.counter.position(), err)
+}
+
+func (xmlReader *XMLReader) readEach(handleRoot func(rootBlock Block) error, handleChild func(childBlock Block) error) error {
+	err := xmlReader.readProlog()
+	if err != nil {
+		return err
 	}
+	openingTag, _, blockComplete, err := xmlReader.readOpeningTag()
+	if err != nil {
+		return err
+	}
+	rootBlock := Block{OpeningTag: openingTag}
+	if err = handleRoot(rootBlock); err != nil {
+		return err
+	}
+	if !blockComplete {
+		// namespaces declared by the root tag are in scope till its closing tag.
+		xmlReader.namespaces = append(xmlReader.namespaces, namespaceDeclarations(openingTag))
+		err = xmlReader.readContent(&rootBlock, handleChild)
+		xmlReader.namespaces = xmlReader.namespaces[:len(xmlReader.namespaces)-1]
+		if err != nil {
+			return err
+		}
+		if err = xmlReader.readMatchingClosingTag(openingTag); err != nil {
+			return err
+		}
+	}
+	return xmlReader.readEpilog()
+}
 
-	// after reading the first block ( the root block ),
-	// there shouldn't be any other tags or characters.
-	_, err = xmlReader.ignoreWhiteSpace()
+// after reading the first block ( the root block ),
+// there shouldn't be any other tags or characters except comments and
+// processing instructions.
+func (xmlReader *XMLReader) readEpilog() error {
+	defer xmlReader.CloseFileObj()
+	err := xmlReader.skipMisc()
 	if err == nil {
 		// some other chars were found after reading the rootblock.
 		// expected err to be an EOF error.
 		nextRune, _ := xmlReader.peekARune()
-		return rootBlock, fmt.Errorf("unexpected chars after reading root block. Char Found: %v", string(nextRune))
+		return fmt.Errorf("unexpected chars after reading root block. Char Found: %v", string(nextRune))
 	}
-	return rootBlock, nil
+	return nil
 }
 
+// content of the fileObject must be UTF-8. Documents in other encodings are
+// read by XMLReaderFromFileObjectWithCharsetReader.
 func XMLReaderFromFileObject(fileObject *bufio.Reader) XMLReader {
 	// user will be responsible for closing the file.
-	return XMLReader{fileObject, nil}
+	return XMLReader{fileReader: fileObject}
+}
+
+// XMLReaderFromFileObjectWithCharsetReader converts the content of the
+// fileObject to UTF-8 using NewUTF8Reader before reading it. charsetReader
+// converts the encodings not supported by NewUTF8Reader and may be nil.
+func XMLReaderFromFileObjectWithCharsetReader(fileObject io.Reader, charsetReader CharsetReader) (XMLReader, error) {
+	utf8Reader, err := NewUTF8Reader(fileObject, charsetReader)
+	if err != nil {
+		return XMLReader{}, err
+	}
+	return XMLReaderFromFileObject(utf8Reader), nil
 }
 
 func XMLReaderFromFilePath(filePath string) (xmlReader XMLReader, err error) {
@@ -438,7 +829,12 @@ func XMLReaderFromFilePath(filePath string) (xmlReader XMLReader, err error) {
 		return xmlReader, err
 	}
 
-	xmlReader.fileReader = bufio.NewReader(fileObj)
+	// content of the file is converted to UTF-8 before reading it.
+	xmlReader, err = XMLReaderFromFileObjectWithCharsetReader(fileObj, nil)
+	if err != nil {
+		fileObj.Close()
+		return xmlReader, err
+	}
 	xmlReader.fileObj = fileObj
 	return xmlReader, nil
 }
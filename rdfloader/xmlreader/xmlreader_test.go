@@ -95,30 +95,137 @@ func TestXMLReaderFromFilePath(t *testing.T) {
 }
 
 func TestXMLReader_Read(t *testing.T) {
-	xmlReader := xmlreaderFromString(SampleRDF)
-	root, err := xmlReader.Read()
-	if err != nil {
-		t.Errorf("unexpected error on a valid rdf file. %v", err)
-	}
-	// for the given SampleRDF, the root block has tags as children.
-	// Value attribute of the root tag must be empty.
-	if root.Value != "" {
-		t.Errorf("expected root block value to be empty. Found %v", root.Value)
-	}
-	// root has only one child
-	if nChildren := len(root.Children); nChildren != 1 {
-		t.Errorf("expected root tag to have %v children, found %v children", 1, nChildren)
-	}
-	// root has 3 attributes
-	if lenAttr := len(root.OpeningTag.Attrs); lenAttr != 3 {
-		t.Errorf("expected root attribute to have %v attributes, found %v attributes", 3, lenAttr)
+	// every case is run against all the implementations of the Reader.
+	for name, reader := range readersFromString(SampleRDF) {
+		root, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error on a valid rdf file. %v", name, err)
+		}
+		// for the given SampleRDF, the root block has tags as children.
+		// Value attribute of the root tag must be empty.
+		if root.Value != "" {
+			t.Errorf("%v: expected root block value to be empty. Found %v", name, root.Value)
+		}
+		// root has only one child
+		if nChildren := len(root.Children); nChildren != 1 {
+			t.Errorf("%v: expected root tag to have %v children, found %v children", name, 1, nChildren)
+		}
+		// root has 3 attributes
+		if lenAttr := len(root.OpeningTag.Attrs); lenAttr != 3 {
+			t.Errorf("%v: expected root attribute to have %v attributes, found %v attributes", name, 3, lenAttr)
+		}
 	}
 
 	// TestCase 2: root block followed by any tag or chars must raise an error
-	xmlReader = xmlreaderFromString(SampleRDF + "\n<tag/>")
-	_, err = xmlReader.Read()
-	if err == nil {
-		t.Error("expected Read() to raise an error")
+	for name, reader := range readersFromString(SampleRDF + "\n<tag/>") {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected Read() to raise an error", name)
+		}
+	}
+
+	// TestCase 3: comments, processing instructions and doctype are skipped.
+	// entities declared by the doctype are expanded.
+	testString := `<?xml version="1.0"?>
+		<!-- generated document -->
+		<!DOCTYPE rdf:RDF [
+			<!-- don't expand parameter entities -->
+			<!ENTITY spdx "http://spdx.org/rdf/terms#">
+		]>
+		<?xml-stylesheet href="style.xsl?v=1"?>
+		<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="&spdx;">
+			<!-- <spdx:File rdf:about="#commented"/> -->
+			<spdx:File rdf:about="&spdx;File">
+				<spdx:fileName>a.go<!-- comment within a value -->/b.go</spdx:fileName>
+				<?pi?>
+			</spdx:File>
+			<!-- comment before the closing tag -->
+		</rdf:RDF>
+		<!-- trailing comment -->
+		`
+	for name, reader := range readersFromString(testString) {
+		root, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if root.OpeningTag.Attrs[1].Value != "http://spdx.org/rdf/terms#" {
+			t.Errorf("%v: expected the entity in the namespace to be expanded, found %v", name, root.OpeningTag.Attrs[1].Value)
+		}
+		if nChildren := len(root.Children); nChildren != 1 {
+			t.Errorf("%v: expected root tag to have %v children, found %v children", name, 1, nChildren)
+			continue
+		}
+		file := root.Children[0]
+		if about := file.OpeningTag.Attrs[0].Value; about != "http://spdx.org/rdf/terms#File" {
+			t.Errorf("%v: expected rdf:about to be http://spdx.org/rdf/terms#File, found %v", name, about)
+		}
+		if len(file.Children) != 1 || file.Children[0].Value != "a.go/b.go" {
+			t.Errorf("%v: expected a single child with value a.go/b.go, found %v", name, file.Children)
+		}
+	}
+
+	// TestCase 4: undeclared entities must raise an error.
+	for name, reader := range readersFromString(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" rdf:about="&spdx;"/>`) {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error for an undeclared entity", name)
+		}
+	}
+
+	// TestCase 5: unterminated comments must raise an error.
+	for name, reader := range readersFromString(`<!-- comment <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>`) {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error for an unterminated comment", name)
+		}
+	}
+}
+
+func TestXMLReader_ReadEach(t *testing.T) {
+	testString := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns:spdx="http://spdx.org/rdf/terms#">
+		<spdx:File rdf:about="#SPDXRef-1"><spdx:fileName>a.go</spdx:fileName></spdx:File>
+		<!-- comment between the node tags -->
+		<spdx:File rdf:about="#SPDXRef-2"/>
+	</rdf:RDF>`
+
+	// TestCase 1: children of the root block are read one at a time and
+	// are same as the children read by Read.
+	for name, reader := range readersFromString(testString) {
+		expected, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		var root Block
+		var children []*Block
+		err = readersFromString(testString)[name].ReadEach(func(rootBlock Block) error {
+			root = rootBlock
+			return nil
+		}, func(childBlock Block) error {
+			children = append(children, &childBlock)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(root.OpeningTag, expected.OpeningTag) || len(root.Children) != 0 {
+			t.Errorf("%v: expected root block %+v without children, found %+v", name, expected.OpeningTag, root)
+		}
+		if !reflect.DeepEqual(children, expected.Children) {
+			t.Errorf("%v: expected children %+v, found %+v", name, expected.Children, children)
+		}
+	}
+
+	// TestCase 2: error returned by a handler stops the reading.
+	for name, reader := range readersFromString(testString) {
+		nChildren := 0
+		err := reader.ReadEach(func(rootBlock Block) error {
+			return nil
+		}, func(childBlock Block) error {
+			nChildren++
+			return fmt.Errorf("handler error")
+		})
+		if err == nil || err.Error() != "handler error" || nChildren != 1 {
+			t.Errorf("%v: expected the handler error after the first child, found %v after %v children", name, err, nChildren)
+		}
 	}
 }
 
@@ -154,6 +261,96 @@ func TestXMLReader_readAttribute(t *testing.T) {
 	}
 }
 
+func TestXMLReader_readAttribute_whitespace(t *testing.T) {
+	// attribute values can have blank characters.
+	xmlReader := xmlreaderFromString(`spdx:name='Apache License 2.0' />`)
+	attr, err := xmlReader.readAttribute()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if attr.Value != "Apache License 2.0" {
+		t.Errorf("Wrong attribute value. Found %v, Expected %v", attr.Value, "Apache License 2.0")
+	}
+
+	// attribute value without a closing quote must raise an error.
+	xmlReader = xmlreaderFromString(`spdx:name="Apache License 2.0`)
+	_, err = xmlReader.readAttribute()
+	if err == nil {
+		t.Error("expected an error reporting missing closing quote")
+	}
+}
+
+func TestXMLReader_readBlock_references(t *testing.T) {
+	// references in the values and in the attributes must be decoded.
+	testString := `<spdx:File spdx:fileName="a&amp;b &#x2F; &quot;c&quot;">
+		<spdx:copyrightText>Copyright &#169; 2020 Smith &amp; Sons &lt;smith@example.com&gt;</spdx:copyrightText>
+	</spdx:File>`
+	for name, reader := range readersFromString(testString) {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if value := block.OpeningTag.Attrs[0].Value; value != `a&b / "c"` {
+			t.Errorf("%v: expected attribute value %v, found %v", name, `a&b / "c"`, value)
+		}
+		if len(block.Children) != 1 {
+			t.Errorf("%v: expected exactly one child, found %v", name, block.Children)
+			continue
+		}
+		expected := "Copyright © 2020 Smith & Sons <smith@example.com>"
+		if value := block.Children[0].Value; value != expected {
+			t.Errorf("%v: expected value %v, found %v", name, expected, value)
+		}
+	}
+
+	// undefined entities must raise an error.
+	for name, reader := range readersFromString(`<spdx:copyrightText>&copy; 2020</spdx:copyrightText>`) {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error for an undefined entity", name)
+		}
+	}
+}
+
+func TestXMLReader_readBlock_whitespace(t *testing.T) {
+	// TestCase 1: values are read as they are including the whitespaces.
+	values := map[string]string{
+		"<spdx:name>  Apache License\n\t2.0  </spdx:name>": "  Apache License\n\t2.0  ",
+		"<spdx:name>   </spdx:name>":                       "   ",
+		"<spdx:name></spdx:name>":                          "",
+		"<spdx:name> a <!-- comment --> b </spdx:name>":    " a  b ",
+	}
+	for testString, expected := range values {
+		for name, reader := range readersFromString(testString) {
+			block, err := reader.Read()
+			if err != nil {
+				t.Errorf("%v: unexpected error reading %v: %v", name, testString, err)
+			}
+			if block.Value != expected {
+				t.Errorf("%v: expected %q, found %q", name, expected, block.Value)
+			}
+		}
+	}
+
+	// TestCase 2: whitespaces between the tags are not a value.
+	for name, reader := range readersFromString("<spdx:File>\n  <spdx:name>a</spdx:name>\n</spdx:File>") {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if block.Value != "" || len(block.Children) != 1 {
+			t.Errorf("%v: expected a block with one child and an empty value, found %q with %v children", name, block.Value, len(block.Children))
+		}
+	}
+
+	// TestCase 3: text mixed with the tags must raise an error.
+	for name, reader := range readersFromString("<spdx:File> text <spdx:name>a</spdx:name></spdx:File>") {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error for a text mixed with the tags", name)
+		}
+	}
+}
+
 func TestXMLReader_readBlock(t *testing.T) {
 	// TestCase 1: prolog with only one block
 	testString := `<? xml version="1.0" ?>
@@ -169,36 +366,37 @@ func TestXMLReader_readBlock(t *testing.T) {
 
 	// TestCase 2: block with invalid prolog
 	testString = `<? xml version="1.0" >`
-	xmlReader = xmlreaderFromString(testString)
-	_, err = xmlReader.readBlock()
-	if err == nil {
-		t.Error("expected an error reporting invalid prolog")
+	for name, reader := range readersFromString(testString) {
+		if _, err = reader.Read(); err == nil {
+			t.Errorf("%v: expected an error reporting invalid prolog", name)
+		}
 	}
 
 	// TestCase 3: complete block without a separate closing tag.
 	testString = `<rdf:RDF/>`
-	xmlReader = xmlreaderFromString(testString)
-	block, _ = xmlReader.readBlock()
-	if len(block.Children) != 0 {
-		t.Errorf("expected block to have no children. Found %v children", len(block.Children))
-	}
-	if block.OpeningTag.SchemaName != "rdf" || block.OpeningTag.Name != "RDF" {
-		t.Errorf("expected block opening tag to be <rdf:RDF>, found: <%v:%v>", block.OpeningTag.SchemaName, block.OpeningTag.Name)
+	for name, reader := range readersFromString(testString) {
+		block, _ = reader.Read()
+		if len(block.Children) != 0 {
+			t.Errorf("%v: expected block to have no children. Found %v children", name, len(block.Children))
+		}
+		if block.OpeningTag.SchemaName != "rdf" || block.OpeningTag.Name != "RDF" {
+			t.Errorf("%v: expected block opening tag to be <rdf:RDF>, found: <%v:%v>", name, block.OpeningTag.SchemaName, block.OpeningTag.Name)
+		}
 	}
 
 	// TestCase 4: different opening and closing tag
 	testString = `<rdf:RDF> </rdf:rdf>`
-	xmlReader = xmlreaderFromString(testString)
-	_, err = xmlReader.readBlock()
-	if err == nil {
-		t.Error("should've raised an error reporting different opening and closing tags")
+	for name, reader := range readersFromString(testString) {
+		if _, err = reader.Read(); err == nil {
+			t.Errorf("%v: should've raised an error reporting different opening and closing tags", name)
+		}
 	}
 
 	// TestCase 5: valid case: block with single attribute
-	xmlReader = xmlreaderFromString(SampleRDF)
-	_, err = xmlReader.readBlock()
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+	for name, reader := range readersFromString(SampleRDF) {
+		if _, err = reader.Read(); err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
 	}
 
 	// TestCase 6: invalid case: block with invalid cdata ( cdata without end tag )
@@ -206,33 +404,134 @@ func TestXMLReader_readBlock(t *testing.T) {
 <spdx:extractedText>
     <![CDATA[License by Nomos.
 </spdx:extractedText>`
-    xmlReader = xmlreaderFromString(testString)
-    _, err = xmlReader.readBlock()
-    if err == nil {
-    	t.Error("expected an error saying eof reading cdata end tag")
+	for name, reader := range readersFromString(testString) {
+		if _, err = reader.Read(); err == nil {
+			t.Errorf("%v: expected an error saying eof reading cdata end tag", name)
+		}
 	}
 
-	// TestCase 7: valid case: block with valid cdata.
+	// TestCase 7: valid case: block with valid cdata. value of the block is
+	// the content of the cdata joined with the text around it.
 	testString = `
 <spdx:extractedText>
     <![CDATA[License by Nomos.]]>
 </spdx:extractedText>`
-	xmlReader = xmlreaderFromString(testString)
-	block, err = xmlReader.readBlock()
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
-	}
 	expectedBlock := Block{
 		OpeningTag: Tag{
 			SchemaName: "spdx",
 			Name:       "extractedText",
 			Attrs:      nil,
+			Position:   Position{Offset: 1, Line: 2, Column: 1},
 		},
-		Value:      "<![CDATA[License by Nomos.]]>",
-		Children:   nil,
+		Value:    "\n    License by Nomos.\n",
+		Children: nil,
+	}
+	for name, reader := range readersFromString(testString) {
+		block, err = reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(block, expectedBlock) {
+			t.Errorf("%v: mismatching output. \nExpected: %+v. \nFound: %+v", name, expectedBlock, block)
+		}
 	}
-	if !reflect.DeepEqual(block, expectedBlock) {
-		t.Errorf("mismatching output. \nExpected: %+v. \nFound: %+v", expectedBlock, block)
+}
+
+func TestXMLReader_readBlock_cdata(t *testing.T) {
+	// TestCase 1: text mixed with cdata sections is a single value.
+	// references are replaced only in the text and not in the cdata.
+	testString := `<spdx:supplier>a &amp; <![CDATA[x & <y>]]> b<!-- note --><![CDATA[z]]></spdx:supplier>`
+	for name, reader := range readersFromString(testString) {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if expectedValue := "a & x & <y> bz"; block.Value != expectedValue {
+			t.Errorf("%v: expected value: %q, found: %q", name, expectedValue, block.Value)
+		}
+	}
+
+	// TestCase 2: cdata mixed with the children tags must raise an error.
+	for _, testString := range []string{
+		`<spdx:supplier><![CDATA[x]]><spdx:name>a</spdx:name></spdx:supplier>`,
+		`<spdx:supplier><spdx:name>a</spdx:name><![CDATA[x]]></spdx:supplier>`,
+	} {
+		for name, reader := range readersFromString(testString) {
+			if _, err := reader.Read(); err == nil {
+				t.Errorf("%v: expected an error reporting mixed content in %v", name, testString)
+			}
+		}
+	}
+}
+
+func TestXMLReader_readBlock_xmlLiteral(t *testing.T) {
+	// TestCase 1: content of a block with rdf:parseType="Literal" must be read as it is.
+	testString := `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://www.w3.org/1999/xhtml">
+	<spdx:licenseText rdf:parseType="Literal"> <p class="a>b">Copyright <b>2020</b><br/></p><!-- note --> text </spdx:licenseText>
+</rdf:RDF>`
+	for name, reader := range readersFromString(testString) {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if len(block.Children) != 1 {
+			t.Errorf("%v: expected root to have exactly one child, found %v", name, len(block.Children))
+			continue
+		}
+		literalBlock := block.Children[0]
+		// the default namespace in scope must be declared in the outermost tag of the literal.
+		expectedValue := ` <p xmlns="http://www.w3.org/1999/xhtml" class="a>b">Copyright <b>2020</b><br/></p><!-- note --> text `
+		if literalBlock.Value != expectedValue {
+			t.Errorf("%v: expected value: %v, found: %v", name, expectedValue, literalBlock.Value)
+		}
+		if len(literalBlock.Children) != 0 {
+			t.Errorf("%v: xml literal must not have any children. Found %v", name, literalBlock.Children)
+		}
+	}
+
+	// TestCase 2: rdf:parseType="Resource" must be read as children blocks.
+	testString = `<spdx:checksum xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" rdf:parseType="Resource">
+		<spdx:algorithm>SHA1</spdx:algorithm>
+	</spdx:checksum>`
+	for name, reader := range readersFromString(testString) {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if len(block.Children) != 1 {
+			t.Errorf("%v: expected block to have exactly one child, found %v", name, len(block.Children))
+		}
+	}
+
+	// TestCase 3: xml literal without closing tag must raise an error.
+	testString = `<spdx:licenseText rdf:parseType="Literal"><p>text</p>`
+	for name, reader := range readersFromString(testString) {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error reporting eof reading the xml literal", name)
+		}
+	}
+
+	// TestCase 4: xml literal with mismatching closing tag must raise an error.
+	testString = `<spdx:licenseText rdf:parseType="Literal"><p>text</p></spdx:text>`
+	for name, reader := range readersFromString(testString) {
+		if _, err := reader.Read(); err == nil {
+			t.Errorf("%v: expected an error reporting mismatching tags", name)
+		}
+	}
+
+	// TestCase 5: non-ascii characters of a xml literal must be read as they are.
+	testString = `<spdx:licenseText xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" rdf:parseType="Literal"><b>© café</b> 日本</spdx:licenseText>`
+	for name, reader := range readersFromString(testString) {
+		block, err := reader.Read()
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if expectedValue := "<b>© café</b> 日本"; block.Value != expectedValue {
+			t.Errorf("%v: expected value: %v, found: %v", name, expectedValue, block.Value)
+		}
 	}
 }
 
@@ -518,8 +817,8 @@ func TestXMLReader_readCDATA(t *testing.T) {
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
-	expectedOutput := CDATA_OPENING + data + CDATA_CLOSING
-	if output != expectedOutput {
-		t.Errorf("expected: %s as the output, found: %s", fileContent, output)
+	// content of the cdata section is returned without the cdata tags.
+	if output != data {
+		t.Errorf("expected: %s as the output, found: %s", data, output)
 	}
 }
@@ -2,9 +2,12 @@ package rdfwriter
 
 import (
 	"fmt"
+	"github.com/spdx/gordf/namespace"
+	"github.com/spdx/gordf/namespace/rdf"
 	"github.com/spdx/gordf/rdfloader/parser"
 	"github.com/spdx/gordf/uri"
 	"io"
+	"sort"
 	"strings"
 )
 
@@ -24,25 +27,36 @@ func FilterTriples(triples []*parser.Triple, subject, predicate, object *string)
 }
 
 // returns the string form of the root tag with all the uri definitions
-func getRootTagFromSchemaDefinition(schemaDefinition map[string]uri.URIRef, tab string) string {
-	rootTag := "<rdf:RDF\n"
+// in the sorted order of their prefixes. base is written as the xml:base of
+// the root tag if it isn't empty.
+func getRootTagFromSchemaDefinition(schemaDefinition map[string]uri.URIRef, base string, tab string) string {
+	tags := make([]string, 0, len(schemaDefinition))
 	for tag := range schemaDefinition {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	rootTag := "<rdf:RDF\n"
+	for _, tag := range tags {
 		tagURI := schemaDefinition[tag]
 		if tag == "" {
-			rootTag += tab + fmt.Sprintf(`%s="%s"`, "xmlns", tagURI.String()) + "\n"
+			rootTag += tab + fmt.Sprintf(`%s="%s"`, "xmlns", escapeAttribute(tagURI.String())) + "\n"
 		} else {
-			rootTag += tab + fmt.Sprintf(`%s:%s="%s"`, "xmlns", tag, tagURI.String()) + "\n"
+			rootTag += tab + fmt.Sprintf(`%s:%s="%s"`, "xmlns", tag, escapeAttribute(tagURI.String())) + "\n"
 		}
 	}
+	if base != "" {
+		rootTag += tab + fmt.Sprintf(`xml:base="%s"`, escapeAttribute(base)) + "\n"
+	}
 	rootTag = rootTag[:len(rootTag)-1] // removing the last \n char.
 	rootTag += ">"
 	return rootTag
 }
 
 // returns the string form of the opening and closing tag from the given triples.
-func getOpeningAndClosingTags(triples []*parser.Triple, rdfNSAbbrev string, invSchemaDefinition map[string]string, tabs string, node *parser.Node) (openingTag string, closingTag string, err error) {
-	rdfTypeURI := parser.RDFNS + "type"
-	rdfNodeIDURI := parser.RDFNS + "nodeID"
+func getOpeningAndClosingTags(triples []*parser.Triple, rdfNSAbbrev string, namespaces *namespace.Manager, tabs string, node *parser.Node) (openingTag string, closingTag string, err error) {
+	rdfTypeURI := rdf.Type.String()
+	rdfNodeIDURI := rdf.NodeID.String()
 
 	openingTagFormat := "<%s%s%s>"
 	closingTagFormat := "</%s>"
@@ -60,26 +74,30 @@ func getOpeningAndClosingTags(triples []*parser.Triple, rdfNSAbbrev string, invS
 	// 1st %s: same as first %s of openingTagFormat
 
 	rdfTypeTriples := FilterTriples(triples, nil, &rdfTypeURI, nil)
-	if n := len(rdfTypeTriples); n != 1 {
-		return openingTag, closingTag, fmt.Errorf("every subject node must be associated with exactly 1 triple of type rdf:type predicate. Found %v triples", n)
-	}
 	rdfnodeIDTriples := FilterTriples(triples, nil, &rdfNodeIDURI, nil)
+	// untyped nodes are rdf:Description tags.
+	if n := len(rdfTypeTriples); n > 1 {
+		return openingTag, closingTag, fmt.Errorf("every subject node must be associated with at most 1 triple of type rdf:type predicate. Found %v triples", n)
+	}
 	if n := len(rdfnodeIDTriples); n > 1 {
 		return openingTag, closingTag, fmt.Errorf("there must be atmost nodeID attribute. found %v nodeID attributes", n)
 	}
 
 	rdfNodeID := ""
 	if len(rdfnodeIDTriples) == 1 {
-		rdfNodeID = fmt.Sprintf(` %s:nodeID="%s"`, rdfNSAbbrev, rdfnodeIDTriples[0].Object.ID)
+		rdfNodeID = fmt.Sprintf(` %s:nodeID="%s"`, rdfNSAbbrev, escapeAttribute(rdfnodeIDTriples[0].Object.ID))
 	}
 	rdfAbout := ""
 	if node.NodeType == parser.IRI {
-		rdfAbout = fmt.Sprintf(` %s:about="%s"`, rdfNSAbbrev, node.ID)
+		rdfAbout = fmt.Sprintf(` %s:about="%s"`, rdfNSAbbrev, escapeAttribute(node.ID))
 	}
 
-	tagName, err := shortenURI(rdfTypeTriples[0].Object.ID, invSchemaDefinition)
-	if err != nil {
-		return openingTag, closingTag, err
+	tagName := rdfNSAbbrev + ":Description"
+	if len(rdfTypeTriples) == 1 {
+		tagName, err = namespaces.Compact(rdfTypeTriples[0].Object.ID)
+		if err != nil {
+			return openingTag, closingTag, err
+		}
 	}
 
 	openingTag = tabs + fmt.Sprintf(openingTagFormat, tagName, rdfNodeID, rdfAbout)
@@ -87,59 +105,194 @@ func getOpeningAndClosingTags(triples []*parser.Triple, rdfNSAbbrev string, invS
 	return openingTag, closingTag, nil
 }
 
+// returns the attributes to be written in the property tag of a literal object.
+// For example, a typed literal requires a rdf:datatype attribute:
+//
+//	<spdx:created rdf:datatype="http://www.w3.org/2001/XMLSchema#dateTime">
+//
+// and a literal with a language requires a xml:lang attribute:
+//
+//	<rdfs:comment xml:lang="en">
+//
+// For any other node, the output is an empty string.
+func getLiteralAttributes(node *parser.Node, rdfNSAbbrev string) (attributes string) {
+	if node.NodeType != parser.LITERAL {
+		return ""
+	}
+	if node.DataType != "" {
+		attributes += fmt.Sprintf(` %s:datatype="%s"`, rdfNSAbbrev, escapeAttribute(node.DataType))
+	}
+	if node.Lang != "" {
+		attributes += fmt.Sprintf(` xml:lang="%s"`, escapeAttribute(node.Lang))
+	}
+	return attributes
+}
+
+// returns the rdf:ID attribute of the property tag of the triple if the
+// triple is reified by a statement node. The statement node must be an IRI
+// with a fragment. The fragment is the value of rdf:ID and the rest of the IRI
+// is the base uri of the property tag. A xml:base attribute is written only
+// if it isn't same as the base uri in scope.
+// For any other triple, the output is an empty string.
+// tagBase is the base uri in scope of the children of the property tag.
+func getStatementAttributes(triple *parser.Triple, reifiedBy map[string]*parser.Node, base string, rdfNSAbbrev string) (attributes string, tagBase string) {
+	statement, exists := reifiedBy[triple.Hash()]
+	if !exists {
+		return "", base
+	}
+	fragment := getFragment(statement.ID)
+	attributes = fmt.Sprintf(` %s:ID="%s"`, rdfNSAbbrev, escapeAttribute(fragment))
+	if statementBase := strings.TrimSuffix(statement.ID, "#"+fragment); statementBase != base {
+		attributes += fmt.Sprintf(` xml:base="%s"`, escapeAttribute(statementBase))
+		base = statementBase
+	}
+	return attributes, base
+}
+
 // returns the string equivalent of the triples associated with the given node in rdf/xml format.
-func stringify(node *parser.Node, nodeToTriples map[string][]*parser.Triple, invSchemaDefinition map[string]string, depth int, tab string) (output string, err error) {
+// reifiedBy maps the hash of a reified triple to its statement node.
+// base is the base uri in scope of the node tag.
+func stringify(node *parser.Node, nodeToTriples map[string][]*parser.Triple, reifiedBy map[string]*parser.Node, base string, namespaces *namespace.Manager, depth int, tab string) (output string, err error) {
 	// Any rdf/xml tag is formed of OpeningTag, childrenString, ClosingTag
 	var openingTag, childrenString, closingTag string
 
 	tabs := strings.Repeat(tab, depth)
 
 	// getting the abbreviation used for rdf namespace.
-	rdfNSAbbrev := getRDFNSAbbreviation(invSchemaDefinition)
+	rdfNSAbbrev := getRDFNSAbbreviation(namespaces)
 
-	openingTag, closingTag, err = getOpeningAndClosingTags(nodeToTriples[node.String()], rdfNSAbbrev, invSchemaDefinition, tabs, node)
+	openingTag, closingTag, err = getOpeningAndClosingTags(nodeToTriples[node.String()], rdfNSAbbrev, namespaces, tabs, node)
 	if err != nil {
 		return
 	}
 
+	// we'll be parsing one level deep now.
+	childrenString, err = stringifyProperties(node, nodeToTriples, reifiedBy, base, namespaces, depth+1, tab)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s\n%v\n%s", openingTag, childrenString, closingTag), nil
+}
+
+// returns the string equivalent of the property tags of the given node.
+// depth is the depth of the property tags and not that of the node.
+func stringifyProperties(node *parser.Node, nodeToTriples map[string][]*parser.Triple, reifiedBy map[string]*parser.Node, base string, namespaces *namespace.Manager, depth int, tab string) (childrenString string, err error) {
+	tabs := strings.Repeat(tab, depth)
+	rdfNSAbbrev := getRDFNSAbbreviation(namespaces)
+
 	// getting rest of the triples after rdf attributes are parsed
 	restTriples := getRestTriples(nodeToTriples[node.String()])
 
-	depth++     // we'll be parsing one level deep now.
-	tabs += tab // or strings.Repeat(tab, depth)
+	// members of a container are written in order as rdf:li tags if they
+	// are numbered without any gap. Otherwise, as rdf:_n tags.
+	restTriples, isLi := sortContainerMembers(restTriples)
+
 	for _, triple := range restTriples {
-		predicateURI, err := shortenURI(triple.Predicate.ID, invSchemaDefinition)
+		predicateURI, err := namespaces.Compact(triple.Predicate.ID)
 		if err != nil {
 			return "", err
 		}
+		if _, isMember := rdf.MemberIndex(triple.Predicate.ID); isMember && isLi {
+			predicateURI = rdfNSAbbrev + ":li"
+		}
+		// name of the property tag along with the attributes of the reification, if any.
+		statementAttributes, tagBase := getStatementAttributes(triple, reifiedBy, base, rdfNSAbbrev)
+		predicateTag := predicateURI + statementAttributes
 
 		if triple.Object.NodeType == parser.RESOURCELITERAL {
-			childrenString += tabs + fmt.Sprintf(`<%s %s:resource="%s"/>`, predicateURI, rdfNSAbbrev, triple.Object.ID) + "\n"
+			childrenString += tabs + fmt.Sprintf(`<%s %s:resource="%s"/>`, predicateTag, rdfNSAbbrev, escapeAttribute(triple.Object.ID)) + "\n"
 			continue
 		}
 
-		var childString string
-		// adding opening tag to the child tag:
-		childString += tabs + fmt.Sprintf("<%s>", predicateURI) + "\n"
-		if len(nodeToTriples[triple.Object.String()]) == 0 {
-			// the tag ends here and doesn't have any further childs.
-			// object is even one level deep
-			// number of tabs increases.
-			childString += strings.Repeat(tab, depth+1) + triple.Object.ID
-		} else {
-			// we have a sub-child which is not a literal type. it can be a blank or a IRI node.
-			temp, err := stringify(triple.Object, nodeToTriples, invSchemaDefinition, depth+1, tab)
+		if triple.Object.NodeType == parser.LITERAL && triple.Object.DataType == rdf.XMLLiteral.String() {
+			// xml literals are written as they are without any indentation
+			// because whitespaces are significant in a xml literal.
+			childrenString += tabs + fmt.Sprintf(`<%s %s:parseType="Literal">%s</%s>`, predicateTag, rdfNSAbbrev, triple.Object.ID, predicateURI) + "\n"
+			continue
+		}
+
+		if nodeID, ok := getNodeID(triple.Object, nodeToTriples); ok {
+			// blank node written by its rdf:nodeID is only referred by the property tag.
+			childrenString += tabs + fmt.Sprintf(`<%s %s:nodeID="%s"/>`, predicateTag, rdfNSAbbrev, escapeAttribute(nodeID)) + "\n"
+			continue
+		}
+
+		if items, ok := getCollectionItems(triple.Object, nodeToTriples); ok {
+			// well-formed rdf:List is written as a rdf:parseType="Collection"
+			// property tag with a node tag for every item.
+			if len(items) == 0 {
+				childrenString += tabs + fmt.Sprintf(`<%s %s:parseType="Collection"/>`, predicateTag, rdfNSAbbrev) + "\n"
+				continue
+			}
+			childrenString += tabs + fmt.Sprintf(`<%s %s:parseType="Collection">`, predicateTag, rdfNSAbbrev) + "\n"
+			for _, item := range items {
+				itemString, err := stringifyCollectionItem(item, nodeToTriples, reifiedBy, tagBase, namespaces, depth+1, tab)
+				if err != nil {
+					return "", err
+				}
+				childrenString += itemString + "\n"
+			}
+			childrenString += tabs + fmt.Sprintf("</%s>", predicateURI) + "\n"
+			continue
+		}
+
+		if isUntypedBlankNode(triple.Object, nodeToTriples) {
+			// blank node without a type is written as the properties of the
+			// predicate tag using rdf:parseType="Resource"
+			properties, err := stringifyProperties(triple.Object, nodeToTriples, reifiedBy, tagBase, namespaces, depth+1, tab)
 			if err != nil {
 				return "", err
 			}
-			childString += temp
+			if properties == "" {
+				childrenString += tabs + fmt.Sprintf(`<%s %s:parseType="Resource"/>`, predicateTag, rdfNSAbbrev) + "\n"
+				continue
+			}
+			childrenString += tabs + fmt.Sprintf(`<%s %s:parseType="Resource">`, predicateTag, rdfNSAbbrev) + "\n"
+			childrenString += properties + "\n"
+			childrenString += tabs + fmt.Sprintf("</%s>", predicateURI) + "\n"
+			continue
 		}
+
+		if len(nodeToTriples[triple.Object.String()]) == 0 {
+			// the tag ends here and doesn't have any further childs.
+			// the literal is written inline without any indentation
+			// because its whitespaces are a part of its value.
+			childrenString += tabs + fmt.Sprintf("<%s%s>%s</%s>", predicateTag, getLiteralAttributes(triple.Object, rdfNSAbbrev), escapeText(triple.Object.ID), predicateURI) + "\n"
+			continue
+		}
+
+		var childString string
+		// adding opening tag to the child tag:
+		childString += tabs + fmt.Sprintf("<%s%s>", predicateTag, getLiteralAttributes(triple.Object, rdfNSAbbrev)) + "\n"
+		// we have a sub-child which is not a literal type. it can be a blank or a IRI node.
+		temp, err := stringify(triple.Object, nodeToTriples, reifiedBy, tagBase, namespaces, depth+1, tab)
+		if err != nil {
+			return "", err
+		}
+		childString += temp
 		// adding the closing tag
 		childString += "\n" + tabs + fmt.Sprintf("</%s>", predicateURI)
 		childrenString += childString + "\n"
 	}
-	childrenString = strings.TrimSuffix(childrenString, "\n")
-	return fmt.Sprintf("%s\n%v\n%s", openingTag, childrenString, closingTag), nil
+	return strings.TrimSuffix(childrenString, "\n"), nil
+}
+
+// returns the node tag of an item of a rdf:parseType="Collection" property tag.
+// items without any triples are written as an empty rdf:Description tag.
+func stringifyCollectionItem(item *parser.Node, nodeToTriples map[string][]*parser.Triple, reifiedBy map[string]*parser.Node, base string, namespaces *namespace.Manager, depth int, tab string) (string, error) {
+	tabs := strings.Repeat(tab, depth)
+	rdfNSAbbrev := getRDFNSAbbreviation(namespaces)
+	if nodeID, ok := getNodeID(item, nodeToTriples); ok {
+		// blank node written by its rdf:nodeID is only referred by the item.
+		return tabs + fmt.Sprintf(`<%s:Description %s:nodeID="%s"/>`, rdfNSAbbrev, rdfNSAbbrev, escapeAttribute(nodeID)), nil
+	}
+	if len(nodeToTriples[item.String()]) > 0 {
+		return stringify(item, nodeToTriples, reifiedBy, base, namespaces, depth, tab)
+	}
+	if item.NodeType == parser.BLANK {
+		return tabs + fmt.Sprintf(`<%s:Description/>`, rdfNSAbbrev), nil
+	}
+	return tabs + fmt.Sprintf(`<%s:Description %s:about="%s"/>`, rdfNSAbbrev, rdfNSAbbrev, escapeAttribute(item.ID)), nil
 }
 
 // function provided to the user for converting triples to string.
@@ -150,25 +303,50 @@ func stringify(node *parser.Node, nodeToTriples map[string][]*parser.Triple, inv
 //        two-spaces, single tab character, double tab character, etc
 //        depending upon the choice of the user.
 func TriplesToString(triples []*parser.Triple, schemaDefinition map[string]uri.URIRef, tab string) (outputString string, err error) {
+	return TriplesToStringWithNamespaces(triples, namespace.NewManagerFromBindings(schemaDefinition), tab)
+}
+
+// same as TriplesToString but the prefixes are given by a namespace.Manager
+// like the Namespaces of the parser.Parser.
+// uris are written using the longest namespace bound to a prefix.
+// namespaces of the predicates and the types which aren't bound to any prefix
+// are bound to new prefixes like ns1 and ns2 and are written in the root tag.
+// Returns an error if the name of a predicate or a type after its namespace
+// isn't a valid NCName.
+// Prefixes are bound to a copy of the namespaces and not to the namespaces.
+func TriplesToStringWithNamespaces(triples []*parser.Triple, namespaces *namespace.Manager, tab string) (outputString string, err error) {
+	// reifications are written as rdf:ID attributes of the property tags.
+	// base of most of the statement nodes is the xml:base of the document.
+	triples, reifiedBy := CollapseReifications(triples)
+	base := getDocumentBase(reifiedBy)
+	// blank nodes written by their rdf:nodeID are given a rdf:nodeID triple.
+	triples = addNodeIDTriples(triples)
+
+	namespaces = namespaces.Clone()
+	if err = bindUnboundNamespaces(triples, namespaces); err != nil {
+		return outputString, err
+	}
+
 	// linearly ordering the triples in a non-increasing order of depth.
 	sortedTriples, err := TopologicalSortTriples(triples)
 	if err != nil {
 		return outputString, err
 	}
 
-	invSchemaDefinition := invertSchemaDefinition(schemaDefinition)
 	nodeToTriples := GetNodeToTriples(sortedTriples)
 	rootTags := GetRootNodes(sortedTriples)
+	// blank nodes referred by their rdf:nodeID are written at the top level.
+	rootTags = append(rootTags, getNodeIDRoots(sortedTriples, rootTags, nodeToTriples)...)
 
 	// now, we can iterate over all the root-nodes and generate the string representation of the nodes.
 	for _, tag := range rootTags {
-		currString, err := stringify(tag, nodeToTriples, invSchemaDefinition, 1, tab)
+		currString, err := stringify(tag, nodeToTriples, reifiedBy, base, namespaces, 1, tab)
 		if err != nil {
 			return outputString, err
 		}
 		outputString += currString + "\n"
 	}
-	rootTagString := getRootTagFromSchemaDefinition(schemaDefinition, tab)
+	rootTagString := getRootTagFromSchemaDefinition(namespaces.Bindings(), base, tab)
 	rootEndTag := "</rdf:RDF>"
 	return fmt.Sprintf("%s\n%s%s", rootTagString, outputString, rootEndTag), nil
 }
@@ -185,3 +363,13 @@ func WriteToFile(w io.Writer, triples []*parser.Triple, schemaDefinition map[str
 	_, err = fmt.Fprint(w, opString)
 	return err
 }
+
+// same as WriteToFile but the prefixes are given by a namespace.Manager.
+func WriteToFileWithNamespaces(w io.Writer, triples []*parser.Triple, namespaces *namespace.Manager, tab string) error {
+	opString, err := TriplesToStringWithNamespaces(triples, namespaces, tab)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, opString)
+	return err
+}
@@ -2,9 +2,12 @@ package rdfwriter
 
 import (
 	"bytes"
+	"github.com/spdx/gordf/namespace"
+	"github.com/spdx/gordf/rdfloader"
 	"github.com/spdx/gordf/rdfloader/parser"
 	"github.com/spdx/gordf/uri"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -26,9 +29,9 @@ func getSampleSchemaDefinition() map[string]uri.URIRef {
 func TestFilterTriples(t *testing.T) {
 	nodes := getNBlankNodes(10)
 	triples := []*parser.Triple{
-		{nodes[0], nodes[1], nodes[2]},
-		{nodes[3], nodes[1], nodes[4]},
-		{nodes[3], nodes[5], nodes[4]},
+		{Subject: nodes[0], Predicate: nodes[1], Object: nodes[2]},
+		{Subject: nodes[3], Predicate: nodes[1], Object: nodes[4]},
+		{Subject: nodes[3], Predicate: nodes[5], Object: nodes[4]},
 	}
 
 	// TestCase 1: Default Filtering: must return all the triples
@@ -76,8 +79,8 @@ func TestTriplesToString(t *testing.T) {
 	bnodes := getNBlankNodes(5)
 
 	// TestCase 1: error raised by stringify must be returned by the function too.
-	// stringify will complain that every subject node must be associated with
-	// at least one triple with predicate rdf:type
+	// stringify will complain that the blank node predicate can't be written
+	// as a tag.
 	triples = append(triples, &parser.Triple{
 		Subject:   bnodes[0],
 		Predicate: bnodes[1],
@@ -120,6 +123,391 @@ func TestTriplesToString(t *testing.T) {
 	}
 }
 
+// spdx document with characters which must be escaped in a xml document.
+const copyrightRDF = `<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF
+	xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlns:spdx="http://spdx.org/rdf/terms#">
+	<spdx:SpdxDocument rdf:about="http://spdx.org/spdxdocs/doc?name=a&amp;b#SPDXRef-DOCUMENT" spdx:name="Smith &amp; Sons &quot;core&quot;">
+		<spdx:describesPackage>
+			<spdx:Package rdf:about="http://spdx.org/spdxdocs/doc?name=a&amp;b#SPDXRef-1">
+				<spdx:copyrightText>Copyright &#169; 2004-2020 Smith &amp; Sons &lt;legal@smith.example&gt;</spdx:copyrightText>
+				<spdx:licenseComments xml:lang="de">&#x00A9; Jürgen Müller, 1 &lt; 2 &amp;&amp; 3 &gt; 2</spdx:licenseComments>
+				<spdx:downloadLocation rdf:resource="http://example.com/download?pkg=core&amp;version=1.0"/>
+				<spdx:supplier><![CDATA[Organization: Smith & Sons <legal@smith.example>]]></spdx:supplier>
+				<spdx:licenseText>
+    Licensed under the MIT license.
+	See LICENSE for details.  </spdx:licenseText>
+				<spdx:comment>  </spdx:comment>
+			</spdx:Package>
+		</spdx:describesPackage>
+	</spdx:SpdxDocument>
+</rdf:RDF>`
+
+// returns the triples of the document as (subject, predicate, object) strings.
+func tripleSet(t *testing.T, document string, options ...rdfloader.Option) map[[3]string]bool {
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(document), options...)
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v\n%v", err, document)
+		return nil
+	}
+	triples := map[[3]string]bool{}
+	for _, triple := range rdfParser.Triples {
+		triples[[3]string{triple.Subject.String(), triple.Predicate.String(), triple.Object.String()}] = true
+	}
+	return triples
+}
+
+func TestTriplesToString_roundTrip(t *testing.T) {
+	// document written by the writer must have the same triples as the input.
+	inputTriples := tripleSet(t, copyrightRDF)
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(copyrightRDF))
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return
+	}
+	output, err := TriplesToString(rdfParser.Triples, rdfParser.SchemaDefinition, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	outputTriples := tripleSet(t, output)
+	if !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v\nOutput:\n%v", inputTriples, outputTriples, output)
+	}
+	// documents must have the same triples irrespective of the reader.
+	if tokenReaderTriples := tripleSet(t, output, rdfloader.WithTokenReader()); !reflect.DeepEqual(outputTriples, tokenReaderTriples) {
+		t.Errorf("triples read by the token reader are different. Expected:\n%v\nFound:\n%v", outputTriples, tokenReaderTriples)
+	}
+	for _, expected := range []string{
+		"Copyright © 2004-2020 Smith &amp; Sons &lt;legal@smith.example&gt;",
+		`download?pkg=core&amp;version=1.0"`,
+		// content of a cdata section is written as an escaped text.
+		"<spdx:supplier>Organization: Smith &amp; Sons &lt;legal@smith.example&gt;</spdx:supplier>",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in the output:\n%v", expected, output)
+		}
+	}
+}
+
+func TestTriplesToString_propertyAttributes(t *testing.T) {
+	// property attributes of the empty property tags describe untyped
+	// nodes. They are written as rdf:Description tags.
+	document := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1" spdx:fileName="./main.c">
+				<spdx:checksum rdf:resource="http://spdx.org/spdxdocs/doc#checksum-1" spdx:checksumValue="d6a770ba38583ed4bb4525bd96e50461655d2758"/>
+				<spdx:annotation spdx:comment="reviewed"/>
+			</spdx:File>
+		</rdf:RDF>`
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(document))
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return
+	}
+	output, err := TriplesToStringWithNamespaces(rdfParser.Triples, rdfParser.Namespaces, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	if !strings.Contains(output, `<rdf:Description rdf:about="http://spdx.org/spdxdocs/doc#checksum-1">`) {
+		t.Errorf("expected the checksum to be a rdf:Description tag. Output:\n%v", output)
+	}
+	if inputTriples, outputTriples := tripleSet(t, document), tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v\nOutput:\n%v", inputTriples, outputTriples, output)
+	}
+}
+
+func TestTriplesToString_reifications(t *testing.T) {
+	// xml:base of the document is the base of most of the statements. Only
+	// the property tags of the other statements have a xml:base.
+	document := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#"
+			xml:base="http://spdx.org/spdxdocs/doc">
+			<spdx:File rdf:about="#SPDXRef-1">
+				<spdx:licenseConcluded rdf:ID="concluded-1" rdf:resource="http://spdx.org/licenses/MIT"/>
+				<spdx:copyrightText rdf:ID="copyright-1">NOASSERTION</spdx:copyrightText>
+				<spdx:checksum rdf:ID="checksum-1" xml:base="http://spdx.org/spdxdocs/other">
+					<spdx:Checksum>
+						<spdx:checksumValue rdf:ID="value-1">d6a770ba38583ed4bb4525bd96e50461655d2758</spdx:checksumValue>
+						<spdx:algorithm rdf:ID="algorithm-1" xml:base="http://spdx.org/spdxdocs/doc" rdf:resource="http://spdx.org/rdf/terms#checksumAlgorithm_sha1"/>
+					</spdx:Checksum>
+				</spdx:checksum>
+			</spdx:File>
+		</rdf:RDF>`
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(document))
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return
+	}
+	output, err := TriplesToStringWithNamespaces(rdfParser.Triples, rdfParser.Namespaces, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	for _, expected := range []string{
+		`xml:base="http://spdx.org/spdxdocs/doc">`,
+		`<spdx:licenseConcluded rdf:ID="concluded-1" rdf:resource="http://spdx.org/licenses/MIT"/>`,
+		`<spdx:copyrightText rdf:ID="copyright-1">NOASSERTION</spdx:copyrightText>`,
+		`<spdx:checksum rdf:ID="checksum-1" xml:base="http://spdx.org/spdxdocs/other">`,
+		`<spdx:checksumValue rdf:ID="value-1">`,
+		// base in scope of the checksum is the base of its property tag.
+		`<spdx:algorithm rdf:ID="algorithm-1" xml:base="http://spdx.org/spdxdocs/doc" rdf:resource=`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in the output:\n%v", expected, output)
+		}
+	}
+	if n := strings.Count(output, "xml:base"); n != 3 {
+		t.Errorf("expected 3 xml:base attributes, found %v in the output:\n%v", n, output)
+	}
+	if inputTriples, outputTriples := tripleSet(t, document), tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v\nOutput:\n%v", inputTriples, outputTriples, output)
+	}
+}
+
+func TestTriplesToStringWithNamespaces(t *testing.T) {
+	// prefixes of the document are used for writing the hash and the slash
+	// namespaces.
+	document := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#"
+			xmlns:dcterms="http://purl.org/dc/terms/">
+			<spdx:File rdf:about="http://example.com/file">
+				<dcterms:title>main.c</dcterms:title>
+				<spdx:fileName>./main.c</spdx:fileName>
+			</spdx:File>
+		</rdf:RDF>`
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(document))
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return
+	}
+	output, err := TriplesToStringWithNamespaces(rdfParser.Triples, rdfParser.Namespaces, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	for _, expected := range []string{`xmlns:dcterms="http://purl.org/dc/terms/"`, "<dcterms:title>main.c</dcterms:title>", "<spdx:File "} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in the output:\n%v", expected, output)
+		}
+	}
+	if inputTriples, outputTriples := tripleSet(t, document), tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v", inputTriples, outputTriples)
+	}
+
+	// namespaces without a prefix are bound to a derived prefix or to ns1,
+	// ns2, ... in their sorted order. The given namespaces are not changed.
+	namespaces := namespace.NewManager()
+	output, err = TriplesToStringWithNamespaces(rdfParser.Triples, namespaces, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	for _, expected := range []string{
+		`xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"`,
+		`xmlns:terms="http://purl.org/dc/terms/"`,
+		`xmlns:ns1="http://spdx.org/rdf/terms#"`,
+		"<terms:title>main.c</terms:title>",
+		"<ns1:File ",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %v in the output:\n%v", expected, output)
+		}
+	}
+	if inputTriples, outputTriples := tripleSet(t, document), tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v", inputTriples, outputTriples)
+	}
+	if len(namespaces.Bindings()) != 0 {
+		t.Errorf("expected the namespaces to not have any binding, found %v", namespaces.Bindings())
+	}
+}
+
+func TestTriplesToStringWithNamespaces_xmlNames(t *testing.T) {
+	subject := &parser.Node{NodeType: parser.IRI, ID: "http://example.com/doc#file"}
+	object := &parser.Node{NodeType: parser.LITERAL, ID: "main.c"}
+
+	// TestCase 1: predicates ending in a valid NCName are written using a
+	// valid prefix and have the same iris after parsing the output.
+	triples := []*parser.Triple{{
+		Subject:   subject,
+		Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+		Object:    &parser.Node{NodeType: parser.IRI, ID: "http://example.com/terms/File"},
+	}}
+	for _, predicate := range []string{
+		"http://example.com/v1.0/file-name",
+		"http://example.com/terms/a.b",
+		// 1st can't be a prefix.
+		"http://example.com/1st/name",
+	} {
+		triples = append(triples, &parser.Triple{
+			Subject:   subject,
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: predicate},
+			Object:    object,
+		})
+	}
+	output, err := TriplesToStringWithNamespaces(triples, namespace.NewManager(), "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	inputTriples := map[[3]string]bool{}
+	for _, triple := range triples {
+		inputTriples[[3]string{triple.Subject.String(), triple.Predicate.String(), triple.Object.String()}] = true
+	}
+	if outputTriples := tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v\nOutput:\n%v", inputTriples, outputTriples, output)
+	}
+	if !strings.Contains(output, `xmlns:ns1="http://example.com/1st/"`) {
+		t.Errorf("expected http://example.com/1st/ to be bound to ns1. Output:\n%v", output)
+	}
+
+	// TestCase 2: predicates which don't end in a valid NCName can't be
+	// written even if their namespace is bound.
+	namespaces := namespace.NewManager()
+	terms, _ := uri.NewURIRef("http://example.com/terms/")
+	namespaces.Bind("terms", terms)
+	for _, predicate := range []string{
+		"http://example.com/p?x=1",
+		"http://example.com/terms/1st",
+		"http://example.com/terms/a:b",
+		"http://example.com/terms/",
+	} {
+		triples := []*parser.Triple{{
+			Subject:   subject,
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: predicate},
+			Object:    object,
+		}}
+		if output, err := TriplesToStringWithNamespaces(triples, namespaces, "  "); err == nil {
+			t.Errorf("expected an error writing %v, found:\n%v", predicate, output)
+		}
+	}
+}
+
+func Test_bindUnboundNamespaces(t *testing.T) {
+	triples := []*parser.Triple{
+		{
+			Subject:   &parser.Node{NodeType: parser.IRI, ID: "http://example.com/doc#file"},
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: "http://example.com/v2/File"},
+		},
+		{
+			Subject:   &parser.Node{NodeType: parser.IRI, ID: "http://example.com/doc#file"},
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: "http://example.com/terms#name"},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: "main.c"},
+		},
+		{
+			Subject:   &parser.Node{NodeType: parser.IRI, ID: "http://example.com/doc#file"},
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: "http://example.org/terms/size"},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: "10"},
+		},
+		{
+			Subject:   &parser.Node{NodeType: parser.IRI, ID: "http://example.com/doc#file"},
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: "http://example.com/xmlTerms#size"},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: "10"},
+		},
+	}
+	// ns1 is already bound. So, the first generated prefix is ns2.
+	namespaces := namespace.NewManager()
+	ns1, _ := uri.NewURIRef("http://example.com/ns1#")
+	namespaces.Bind("ns1", ns1)
+
+	if err := bindUnboundNamespaces(triples, namespaces); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"ns1":   "http://example.com/ns1#",
+		"rdf":   parser.RDFNS,
+		"terms": "http://example.com/terms#",
+		"v2":    "http://example.com/v2/",
+		// prefixes starting with xml are reserved.
+		"ns2": "http://example.com/xmlTerms#",
+		// terms is already bound to another namespace.
+		"ns3": "http://example.org/terms/",
+	}
+	bindings := namespaces.Bindings()
+	if len(bindings) != len(expected) {
+		t.Errorf("expected %v bindings, found %v", len(expected), bindings)
+	}
+	for prefix, ns := range expected {
+		if bound := bindings[prefix]; bound.String() != ns {
+			t.Errorf("expected %v to be bound to %v, found %v", prefix, ns, bound.String())
+		}
+	}
+}
+
+func TestTriplesToString_nodeIDs(t *testing.T) {
+	// TestCase 1: labels of the blank nodes given by rdf:nodeID are same
+	// after writing the document.
+	nodeIDRDF := `
+		<rdf:RDF
+			xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+			xmlns:spdx="http://spdx.org/rdf/terms#">
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">
+				<spdx:licenseConcluded rdf:nodeID="lic"/>
+				<spdx:annotation rdf:nodeID="note"/>
+			</spdx:File>
+			<spdx:File rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-2">
+				<spdx:licenseConcluded rdf:nodeID="lic"/>
+			</spdx:File>
+			<spdx:License rdf:nodeID="lic" spdx:licenseId="MIT"/>
+			<rdf:Description rdf:nodeID="note" spdx:comment="untyped"/>
+			<spdx:Snippet rdf:nodeID="root"/>
+		</rdf:RDF>`
+	inputTriples := tripleSet(t, nodeIDRDF)
+	rdfParser, err := rdfloader.LoadFromReaderObject(strings.NewReader(nodeIDRDF))
+	if err != nil {
+		t.Errorf("unexpected error loading the document: %v", err)
+		return
+	}
+	output, err := TriplesToString(rdfParser.Triples, rdfParser.SchemaDefinition, "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	if outputTriples := tripleSet(t, output); !reflect.DeepEqual(inputTriples, outputTriples) {
+		t.Errorf("triples changed after writing the document. Expected:\n%v\nFound:\n%v\nOutput:\n%v", inputTriples, outputTriples, output)
+	}
+	if n := strings.Count(output, "<spdx:License "); n != 1 {
+		t.Errorf("expected the license to be written once, found %v times in:\n%v", n, output)
+	}
+
+	// TestCase 2: generated blank node referred by more than one triple is
+	// written once and is referred by a rdf:nodeID.
+	bnodes := getNBlankNodes(1)
+	files := []*parser.Node{
+		{NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#SPDXRef-1"},
+		{NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#SPDXRef-2"},
+	}
+	rdfType := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"}
+	spdxChecksum := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#checksum"}
+	triples := []*parser.Triple{
+		{Subject: files[0], Predicate: rdfType, Object: &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#File"}},
+		{Subject: files[1], Predicate: rdfType, Object: &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#File"}},
+		{Subject: files[0], Predicate: spdxChecksum, Object: bnodes[0]},
+		{Subject: files[1], Predicate: spdxChecksum, Object: bnodes[0]},
+		{Subject: bnodes[0], Predicate: rdfType, Object: &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#Checksum"}},
+	}
+	output, err = TriplesToString(triples, getSampleSchemaDefinition(), "  ")
+	if err != nil {
+		t.Errorf("unexpected error writing the triples: %v", err)
+		return
+	}
+	if n := strings.Count(output, `rdf:nodeID="N1"`); n != 3 {
+		t.Errorf("expected the checksum node tag and two references to it, found %v in:\n%v", n, output)
+	}
+	if outputTriples := tripleSet(t, output); len(outputTriples) != len(triples) {
+		t.Errorf("expected %v triples, found %v in:\n%v", len(triples), outputTriples, output)
+	}
+}
+
 func TestWriteToFile(t *testing.T) {
 	// init all required variables for the testing.
 	var triples []*parser.Triple
@@ -162,16 +550,17 @@ func Test_stringify(t *testing.T) {
 	var triples []*parser.Triple
 	nodeToTriples := GetNodeToTriples(triples)
 	schemaDefinition := getSampleSchemaDefinition()
-	invSchemaDefinition := invertSchemaDefinition(schemaDefinition)
+	namespaces := namespace.NewManagerFromBindings(schemaDefinition)
 	depth := 0
 	tab := "  " // 2 spaces as the tabs
 
-	// TestCase 1: Invalid case with no triples belonging to the passed node.
-	// expected error:  every subject node must be associated with exactly 1
-	//                  triple of type rdf:type predicate
-	_, err := stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
-	if err == nil {
-		t.Errorf("expected error stating nodes must have a triple with predicate of rdf:type")
+	// TestCase 1: node without any triple is an empty rdf:Description tag.
+	output, err := stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expectedOutput := "<rdf:Description>\n\n</rdf:Description>"; output != expectedOutput {
+		t.Errorf("expected %v, found %v", expectedOutput, output)
 	}
 
 	// TestCase 2: invalid base uri in the object must return an error
@@ -181,14 +570,14 @@ func Test_stringify(t *testing.T) {
 		Object:    &parser.Node{NodeType: parser.IRI, ID: "https://inexistent.com/uri#fragment"},
 	})
 	nodeToTriples = GetNodeToTriples(triples)
-	_, err = stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	_, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	if err == nil {
 		t.Errorf("expeected an error saying uri not defined in the schemaDefinition")
 	}
 
 	// TestCase 3: valid input with only rdf:type triple
 	triples[0].Object.ID = "http://spdx.org/rdf/terms#Snippet"
-	output, _ := stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	output, _ = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	expectedOutput := `<spdx:Snippet>
 
 </spdx:Snippet>`
@@ -203,7 +592,7 @@ func Test_stringify(t *testing.T) {
 		Object:    &parser.Node{NodeType: parser.LITERAL, ID: "comment"},
 	})
 	nodeToTriples = GetNodeToTriples(triples)
-	_, err = stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	_, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	if err == nil {
 		t.Errorf("expected an error saying invalid predicate uri")
 	}
@@ -215,7 +604,7 @@ func Test_stringify(t *testing.T) {
 		ID:       "http://spdx.org/rdf/terms#checksumAlgorithm_sha256",
 	}
 	nodeToTriples = GetNodeToTriples(triples)
-	output, _ = stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	output, _ = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	expectedOutput = `<spdx:Snippet>
   <spdx:algorithm rdf:resource="http://spdx.org/rdf/terms#checksumAlgorithm_sha256"/>
 </spdx:Snippet>`
@@ -236,7 +625,7 @@ func Test_stringify(t *testing.T) {
 		},
 	}
 	nodeToTriples = GetNodeToTriples(triples)
-	_, err = stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	_, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -287,35 +676,261 @@ func Test_stringify(t *testing.T) {
 		},
 	}
 	nodeToTriples = GetNodeToTriples(triples)
-	output, _ = stringify(bnodes[0], nodeToTriples, invSchemaDefinition, depth, tab)
+	output, _ = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
 	expectedOutput = `<spdx:externalRef>
   <spdx:ExternalRef>
     <spdx:referenceType>
-      <spdx:ReferenceType>
-        http://spdx.org/rdf/references/cpe23Type
-      </spdx:ReferenceType>
+      <spdx:ReferenceType>http://spdx.org/rdf/references/cpe23Type</spdx:ReferenceType>
     </spdx:referenceType>
   </spdx:ExternalRef>
 </spdx:externalRef>`
 	if output != expectedOutput {
 		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
 	}
+
+	// TestCase 7: typed literal must be written with a rdf:datatype attribute.
+	triples = []*parser.Triple{
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "CreationInfo"},
+		},
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "created"},
+			Object: &parser.Node{
+				NodeType: parser.LITERAL,
+				ID:       "2016-09-28T19:13:38Z",
+				DataType: "http://www.w3.org/2001/XMLSchema#dateTime",
+			},
+		},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:CreationInfo>
+  <spdx:created rdf:datatype="http://www.w3.org/2001/XMLSchema#dateTime">2016-09-28T19:13:38Z</spdx:created>
+</spdx:CreationInfo>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 8: literal with a language must be written with a xml:lang attribute.
+	triples[1] = &parser.Triple{
+		Subject:   bnodes[0],
+		Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "comment"},
+		Object:    &parser.Node{NodeType: parser.LITERAL, ID: "commentaire", Lang: "fr"},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:CreationInfo>
+  <spdx:comment xml:lang="fr">commentaire</spdx:comment>
+</spdx:CreationInfo>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 9: blank node object without a rdf:type triple must be
+	// written using rdf:parseType="Resource"
+	triples = []*parser.Triple{
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "File"},
+		},
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "checksum"},
+			Object:    bnodes[1],
+		},
+		{
+			Subject:   bnodes[1],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "algorithm"},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: "SHA1"},
+		},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:File>
+  <spdx:checksum rdf:parseType="Resource">
+    <spdx:algorithm>SHA1</spdx:algorithm>
+  </spdx:checksum>
+</spdx:File>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 10: xml literal must be written as it is using rdf:parseType="Literal"
+	triples = []*parser.Triple{
+		triples[0],
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "licenseText"},
+			Object: &parser.Node{
+				NodeType: parser.LITERAL,
+				ID:       `<b xmlns="http://www.w3.org/1999/xhtml">MIT</b> License`,
+				DataType: parser.RDFNS + "XMLLiteral",
+			},
+		},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:File>
+  <spdx:licenseText rdf:parseType="Literal"><b xmlns="http://www.w3.org/1999/xhtml">MIT</b> License</spdx:licenseText>
+</spdx:File>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 11: well-formed rdf:List must be written using rdf:parseType="Collection"
+	rdfNil := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "nil"}
+	fileNode := &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "File"}
+	triples = []*parser.Triple{
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "Snippet"},
+		},
+		{Subject: bnodes[0], Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "items"}, Object: bnodes[1]},
+		{Subject: bnodes[1], Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "first"}, Object: fileNode},
+		{Subject: bnodes[1], Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "rest"}, Object: rdfNil},
+		{Subject: bnodes[0], Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "emptyItems"}, Object: rdfNil},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:Snippet>
+  <spdx:items rdf:parseType="Collection">
+    <rdf:Description rdf:about="http://spdx.org/rdf/terms#File"/>
+  </spdx:items>
+  <spdx:emptyItems rdf:parseType="Collection"/>
+</spdx:Snippet>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 12: members of a container numbered without any gap must be
+	// written as rdf:li tags in the order of their index.
+	member := func(n string, value string) *parser.Triple {
+		return &parser.Triple{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "_" + n},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: value},
+		}
+	}
+	triples = []*parser.Triple{
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "Seq"},
+		},
+		member("2", "second"),
+		member("10", "tenth"),
+		member("1", "first"),
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// members with a gap are written using their index.
+	expectedOutput = `<rdf:Seq>
+  <rdf:_1>first</rdf:_1>
+  <rdf:_2>second</rdf:_2>
+  <rdf:_10>tenth</rdf:_10>
+</rdf:Seq>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+	triples[2] = member("3", "third")
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, nil, "", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<rdf:Seq>
+  <rdf:li>first</rdf:li>
+  <rdf:li>second</rdf:li>
+  <rdf:li>third</rdf:li>
+</rdf:Seq>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 13: reified triple must be written with a rdf:ID attribute
+	triples = []*parser.Triple{
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
+			Object:    &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "File"},
+		},
+		{
+			Subject:   bnodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: spdxRef.String() + "licenseConcluded"},
+			Object:    &parser.Node{NodeType: parser.RESOURCELITERAL, ID: "http://spdx.org/licenses/MIT"},
+		},
+	}
+	reifiedBy := map[string]*parser.Node{
+		triples[1].Hash(): {NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#concluded-1"},
+	}
+	nodeToTriples = GetNodeToTriples(triples)
+	output, err = stringify(bnodes[0], nodeToTriples, reifiedBy, "http://spdx.org/spdxdocs/doc", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:File>
+  <spdx:licenseConcluded rdf:ID="concluded-1" rdf:resource="http://spdx.org/licenses/MIT"/>
+</spdx:File>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
+
+	// TestCase 14: xml:base is written only if the base of the statement
+	// node isn't the base uri in scope.
+	output, err = stringify(bnodes[0], nodeToTriples, reifiedBy, "http://spdx.org/spdxdocs/other", namespaces, depth, tab)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expectedOutput = `<spdx:File>
+  <spdx:licenseConcluded rdf:ID="concluded-1" xml:base="http://spdx.org/spdxdocs/doc" rdf:resource="http://spdx.org/licenses/MIT"/>
+</spdx:File>`
+	if output != expectedOutput {
+		t.Errorf("mismatching outputs. Expected:\n%v\n Found: \n%v", expectedOutput, output)
+	}
 }
 
 func Test_getOpeningAndClosingTags(t *testing.T) {
 	nodes := getNBlankNodes(10)
 	tab := ""
 	rdfNSAbbrev := "rdf"
-	invSchemaDefinition := map[string]string{
-		"http://www.w3.org/1999/02/22-rdf-syntax-ns": "rdf",
-		"http://spdx.org/rdf/terms":                  "spdx",
-	}
+	namespaces := namespace.NewManagerFromBindings(getSampleSchemaDefinition())
 	var triples []*parser.Triple
 
-	// TestCase 1: empty triple list must return an error
-	_, _, err := getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
-	if err == nil {
-		t.Errorf("function should've raised an error stating every node must be associated with a triple of predicate rdf:type")
+	// TestCase 1: untyped nodes are rdf:Description tags.
+	openingTag, closingTag, err := getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if openingTag != "<rdf:Description>" || closingTag != "</rdf:Description>" {
+		t.Errorf("expected a rdf:Description tag, found %v %v", openingTag, closingTag)
+	}
+	iri := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#SPDXRef-1"}
+	openingTag, _, _ = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, iri)
+	if expected := `<rdf:Description rdf:about="http://spdx.org/spdxdocs/doc#SPDXRef-1">`; openingTag != expected {
+		t.Errorf("expected %v, found %v", expected, openingTag)
 	}
 
 	// TestCase 2: exactly one triple of predicate rdf:type but the object uri is invalid.
@@ -325,14 +940,14 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 		Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"},
 		Object:    nodes[2],
 	})
-	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	if err == nil {
 		t.Errorf("expected an error saying invalid object uri")
 	}
 
 	// TestCase 3: exactly one triple of predicate rdf:type with valid object uri
 	triples[0].Object = &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#Snippet"}
-	openingTag, closingTag, err := getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	openingTag, closingTag, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	expectedOpeningTag, expectedClosingTag := "<spdx:Snippet>", "</spdx:Snippet>"
 	if openingTag != expectedOpeningTag {
 		t.Errorf("wrong opening tag. expected %s, got %s", expectedOpeningTag, openingTag)
@@ -343,7 +958,7 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 
 	// TestCase 3: more than one triple of type rdf:type
 	triples = append(triples, triples[0])
-	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	if err == nil {
 		t.Error("function should raise an error when there are more than one triples having rdf:type predicate")
 	}
@@ -355,9 +970,9 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 	triples = append(triples, &parser.Triple{
 		Subject:   nodes[0],
 		Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "nodeID"},
-		Object:    &parser.Node{parser.LITERAL, "Node34"},
+		Object:    &parser.Node{NodeType: parser.LITERAL, ID: "Node34"},
 	})
-	openingTag, closingTag, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	openingTag, closingTag, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	expectedOpeningTag, expectedClosingTag = `<spdx:Snippet rdf:nodeID="Node34">`, "</spdx:Snippet>"
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
@@ -371,7 +986,7 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 
 	// TestCase 5: more than one nodeID attribute (an invalid case)
 	triples = append(triples, triples[1])
-	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	if err == nil {
 		t.Error("function should raise an error when there are more than one triples having rdf:nodeID predicate")
 	}
@@ -383,7 +998,7 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 	triples[0].Object = nodes[3]
 	// predicate of triples[0] is rdf:type. It expects the object uri of
 	// type baseName:fragment. but nodes[3] is a blank node with ID "N4"
-	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 	if err == nil {
 		t.Error("expected an invalid uri error")
 	}
@@ -401,7 +1016,7 @@ func Test_getOpeningAndClosingTags(t *testing.T) {
 			Object:    &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#Snippet132"},
 		},
 	}
-	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, invSchemaDefinition, tab, nodes[0])
+	_, _, err = getOpeningAndClosingTags(triples, rdfNSAbbrev, namespaces, tab, nodes[0])
 }
 
 func Test_getRootTagFromSchemaDefinition(t *testing.T) {
@@ -409,7 +1024,7 @@ func Test_getRootTagFromSchemaDefinition(t *testing.T) {
 	tab := "  "
 
 	// TestCase 1: empty schema definition
-	rootTag := getRootTagFromSchemaDefinition(schemaDefinition, tab)
+	rootTag := getRootTagFromSchemaDefinition(schemaDefinition, "", tab)
 	expectedOp := "<rdf:RDF>"
 	if rootTag != expectedOp {
 		t.Errorf("incorrect output. expected %s, found %s", expectedOp, rootTag)
@@ -418,15 +1033,31 @@ func Test_getRootTagFromSchemaDefinition(t *testing.T) {
 	// TestCase 2: only one url in the schemaDefinition
 	rdfURI, _ := uri.NewURIRef(parser.RDFNS)
 	schemaDefinition["rdf"] = rdfURI
-	rootTag = getRootTagFromSchemaDefinition(schemaDefinition, tab)
+	rootTag = getRootTagFromSchemaDefinition(schemaDefinition, "", tab)
 	expectedOp = `<rdf:RDF
   xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">`
 	if rootTag != expectedOp {
 		t.Errorf("incorrect output. expected %s, found %s", expectedOp, rootTag)
 	}
 
-	// Note: not checking for outputs with more than one uri because, the
-	// input to the function is a map object and the function iterates over the keys and
-	// linearly generates the output. Since the keys in a map are unordered,
-	// the output might be different on every run.
+	// TestCase 3: uris are written in the sorted order of their prefixes.
+	schemaDefinition = getSampleSchemaDefinition()
+	schemaDefinition[""], _ = uri.NewURIRef("http://example.com/")
+	rootTag = getRootTagFromSchemaDefinition(schemaDefinition, "", tab)
+	expectedOp = `<rdf:RDF
+  xmlns="http://example.com/"
+  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+  xmlns:spdx="http://spdx.org/rdf/terms#">`
+	if rootTag != expectedOp {
+		t.Errorf("incorrect output. expected %s, found %s", expectedOp, rootTag)
+	}
+
+	// TestCase 4: base uri is written as the xml:base after the uris.
+	rootTag = getRootTagFromSchemaDefinition(map[string]uri.URIRef{"rdf": rdfURI}, "http://spdx.org/spdxdocs/doc", tab)
+	expectedOp = `<rdf:RDF
+  xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+  xml:base="http://spdx.org/spdxdocs/doc">`
+	if rootTag != expectedOp {
+		t.Errorf("incorrect output. expected %s, found %s", expectedOp, rootTag)
+	}
 }
@@ -2,8 +2,11 @@ package rdfwriter
 
 import (
 	"fmt"
+	"github.com/spdx/gordf/namespace"
+	"github.com/spdx/gordf/namespace/rdf"
 	"github.com/spdx/gordf/rdfloader/parser"
 	"github.com/spdx/gordf/uri"
+	"sort"
 	"strings"
 )
 
@@ -71,14 +74,17 @@ func GetNodeToTriples(triples []*parser.Triple) (recoveryDS map[string][]*parser
 	return removeDuplicateTriples(recoveryDS)
 }
 
+// returns the triples without duplicates.
+// order of the first occurrence of every triple is preserved.
 func getUniqueTriples(triples []*parser.Triple) []*parser.Triple {
-	set := map[string]*parser.Triple{}
-	for _, triple := range triples {
-		set[triple.Hash()] = triple
-	}
+	set := map[string]bool{}
 	var retList []*parser.Triple
-	for key := range set {
-		retList = append(retList, set[key])
+	for _, triple := range triples {
+		if set[triple.Hash()] {
+			continue
+		}
+		set[triple.Hash()] = true
+		retList = append(retList, triple)
 	}
 	return retList
 }
@@ -208,23 +214,6 @@ func DisjointSet(triples []*parser.Triple) map[*parser.Node]*parser.Node {
 	return parent
 }
 
-// a schemaDefinition is a dictionary which maps the abbreviation defined in the root tag.
-// for example: if the root tag is =>
-//      <rdf:RDF
-//		    xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"/>
-// the schemaDefinition will contain:
-//    {"rdf": "http://www.w3.org/1999/02/22-rdf-syntax-ns#"}
-// this function will output a reverse map that is:
-//    {"http://www.w3.org/1999/02/22-rdf-syntax-ns#": "rdf"}
-func invertSchemaDefinition(schemaDefinition map[string]uri.URIRef) map[string]string {
-	invertedMap := make(map[string]string)
-	for abbreviation := range schemaDefinition {
-		_uri := schemaDefinition[abbreviation]
-		invertedMap[strings.Trim(_uri.String(), "#")] = abbreviation
-	}
-	return invertedMap
-}
-
 // return true if the target is in the given list
 func any(target string, list []string) bool {
 	for _, s := range list {
@@ -235,42 +224,110 @@ func any(target string, list []string) bool {
 	return false
 }
 
-// from the inverted schema definition, returns the name of the prefix used for
-// the rdf name space. Return defaults to "rdf"
-func getRDFNSAbbreviation(invSchemaDefinition map[string]string) string {
+// returns the prefix bound to the rdf namespace. Return defaults to "rdf"
+func getRDFNSAbbreviation(namespaces *namespace.Manager) string {
 	rdfNSAbbrev := "rdf"
-	if abbrev, exists := invSchemaDefinition[parser.RDFNS]; exists {
+	rdfNS, _ := uri.NewURIRef(rdf.NS)
+	if abbrev, exists := namespaces.Prefix(rdfNS); exists && abbrev != "" {
 		rdfNSAbbrev = abbrev
 	}
 	return rdfNSAbbrev
 }
 
-// given an expanded uri, returns abbreviated form for the same.
-// For example:
-// http://www.w3.org/1999/02/22-rdf-syntax-ns#Description will be abbreviated to rdf:Description
-func shortenURI(uri string, invSchemaDefinition map[string]string) (string, error) {
-	// Logic: Every uri with a fragment created by the uri.URIRef has if of
-	// type baseURI#fragment. This function splits the uri by # character and
-	// replaces the baseURI with the abbreviated form from the inverseSchemaDefinition
+// returns the namespace of an iri which can't be compacted. The namespace
+// of an iri ends at its last # or / char. For example:
+//
+//	http://spdx.org/rdf/terms#name -> http://spdx.org/rdf/terms#
+//	http://purl.org/dc/terms/title -> http://purl.org/dc/terms/
+//
+// ok is false if the rest of the iri isn't a valid NCName. Such iris can't be
+// written as the name of a xml tag.
+func splitNamespace(iri string) (ns string, ok bool) {
+	idx := strings.LastIndexAny(iri, "#/")
+	if idx == -1 || !namespace.IsNCName(iri[idx+1:]) {
+		return "", false
+	}
+	return iri[:idx+1], true
+}
+
+// returns the prefix derived from the last segment of the path of the
+// namespace. For example, terms for http://purl.org/dc/terms/
+// Returns an empty string if the segment can't be used as a prefix.
+func derivePrefix(ns string) string {
+	ns = strings.TrimRight(ns, "#/")
+	segment := ns[strings.LastIndex(ns, "/")+1:]
+	if !namespace.IsNCName(segment) || strings.HasPrefix(strings.ToLower(segment), "xml") {
+		// prefixes starting with xml are reserved.
+		return ""
+	}
+	return segment
+}
 
-	splitIndex := strings.LastIndex(uri, "#")
-	if splitIndex == -1 {
-		return "", fmt.Errorf("uri doesn't have two parts of type schemaName:tagName. URI: %s", uri)
+// binds a prefix to the namespace of every predicate and type of the triples
+// which can't be compacted by the prefixes bound to the namespaces.
+// Prefixes are derived from the namespaces by derivePrefix. If the derived
+// prefix is empty or already bound, the namespace is bound to the first of
+// ns1, ns2, ... which isn't bound. Namespaces are bound in their sorted
+// order so that the same triples are always written using the same prefixes.
+// The rdf namespace used by the node tags is bound to rdf if it isn't bound
+// to any prefix.
+// Returns an error if an iri can't be split into a namespace and a name
+// which is a valid NCName.
+func bindUnboundNamespaces(triples []*parser.Triple, namespaces *namespace.Manager) error {
+	if len(triples) == 0 {
+		return nil
+	}
+	unbound := map[string]bool{}
+	rdfNS, _ := uri.NewURIRef(rdf.NS)
+	if _, bound := namespaces.Prefix(rdfNS); !bound && namespaces.Bind("rdf", rdfNS) != nil {
+		unbound[rdf.NS] = true
+	}
+	rdfTypeURI := rdf.Type.String()
+	for _, triple := range triples {
+		iris := []string{triple.Predicate.ID}
+		if triple.Predicate.ID == rdfTypeURI {
+			iris = append(iris, triple.Object.ID)
+		}
+		for _, iri := range iris {
+			if _, err := namespaces.Compact(iri); err == nil {
+				continue
+			}
+			ns, ok := splitNamespace(iri)
+			if !ok {
+				return fmt.Errorf("can't write %v as a xml name. It doesn't end in a valid NCName after its last # or / char", iri)
+			}
+			unbound[ns] = true
+		}
 	}
 
-	baseURI := strings.Trim(uri[:splitIndex], "#")
-	fragment := strings.TrimSuffix(uri[splitIndex+1:], "#") // removing the trailing #.
-	fragment = strings.TrimSpace(fragment)
-	if len(fragment) == 0 {
-		return "", fmt.Errorf(`fragment "%v" doesn't exist`, fragment)
+	var sortedNamespaces []string
+	for ns := range unbound {
+		sortedNamespaces = append(sortedNamespaces, ns)
 	}
-	if abbrev, exists := invSchemaDefinition[baseURI]; exists {
-		if abbrev == "" {
-			return fragment, nil
+	sort.Strings(sortedNamespaces)
+
+	n := 0
+	for _, ns := range sortedNamespaces {
+		nsURI, err := uri.NewURIRef(ns)
+		if err != nil {
+			// iris of the namespace can't be compacted and are reported
+			// while writing them.
+			continue
 		}
-		return fmt.Sprintf("%s:%s", abbrev, fragment), nil
+		prefix := derivePrefix(ns)
+		for prefix == "" || isBound(namespaces, prefix) {
+			n++
+			prefix = fmt.Sprintf("ns%d", n)
+		}
+		namespaces.Bind(prefix, nsURI)
 	}
-	return "", fmt.Errorf("declaration of URI(%s) not found in the schemaDefinition", baseURI)
+	return nil
+}
+
+// returns true if the prefix is bound to a namespace.
+func isBound(namespaces *namespace.Manager, prefix string) bool {
+	_, bound := namespaces.Namespace(prefix)
+	return bound
 }
 
 // from a given adjacency list, return a list of root-nodes which will be used
@@ -293,8 +350,8 @@ func GetRootNodes(triples []*parser.Triple) (rootNodes []*parser.Node) {
 
 // returns the triples that are not associated with tags of schemaName "rdf".
 func getRestTriples(triples []*parser.Triple) (restTriples []*parser.Triple) {
-	rdfTypeURI := parser.RDFNS + "type"
-	rdfNodeIDURI := parser.RDFNS + "nodeID"
+	rdfTypeURI := rdf.Type.String()
+	rdfNodeIDURI := rdf.NodeID.String()
 	for _, triple := range triples {
 		if !any(triple.Predicate.ID, []string{rdfNodeIDURI, rdfTypeURI}) {
 			restTriples = append(restTriples, triple)
@@ -302,3 +359,278 @@ func getRestTriples(triples []*parser.Triple) (restTriples []*parser.Triple) {
 	}
 	return restTriples
 }
+
+// returns the triples along with a rdf:nodeID triple for every blank node
+// which must be written by its rdf:nodeID. Such blank nodes are
+//  1. blank nodes labelled by the rdf:nodeID of the document. They keep
+//     their labels so that the labels are same after loading the output.
+//  2. blank nodes which are the object of more than one triple. They are
+//     written only once and are referred by their rdf:nodeID.
+//
+// generated blank nodes are labelled by their IDs unless the ID is the label
+// of another blank node.
+func addNodeIDTriples(triples []*parser.Triple) []*parser.Triple {
+	rdfNodeIDURI := rdf.NodeID.String()
+	labelled := map[string]bool{}   // string form of the nodes having a label.
+	usedLabels := map[string]bool{} // labels given to the nodes.
+	references := map[string]int{}  // number of triples having the node as object.
+	for _, triple := range triples {
+		if triple.Predicate.ID == rdfNodeIDURI {
+			// label given by the input triples.
+			labelled[triple.Subject.String()] = true
+			usedLabels[triple.Object.ID] = true
+			continue
+		}
+		for _, node := range []*parser.Node{triple.Subject, triple.Object} {
+			if nodeID, ok := node.NodeID(); ok {
+				usedLabels[nodeID] = true
+			}
+		}
+		if triple.Object.NodeType == parser.BLANK {
+			references[triple.Object.String()]++
+		}
+	}
+
+	var nodeIDTriples []*parser.Triple
+	for _, triple := range triples {
+		for _, node := range []*parser.Node{triple.Subject, triple.Object} {
+			if node.NodeType != parser.BLANK || labelled[node.String()] {
+				continue
+			}
+			label, ok := node.NodeID()
+			if !ok {
+				if references[node.String()] < 2 {
+					continue
+				}
+				label = node.ID
+				for usedLabels[label] {
+					label += "_"
+				}
+				usedLabels[label] = true
+			}
+			labelled[node.String()] = true
+			nodeIDTriples = append(nodeIDTriples, &parser.Triple{
+				Subject:   node,
+				Predicate: &parser.Node{NodeType: parser.IRI, ID: rdfNodeIDURI},
+				Object:    &parser.Node{NodeType: parser.LITERAL, ID: label},
+			})
+		}
+	}
+	return append(triples, nodeIDTriples...)
+}
+
+// returns the label of the blank node given by its rdf:nodeID triple.
+// ok is false if the node is not a blank node with exactly one such triple.
+func getNodeID(node *parser.Node, nodeToTriples map[string][]*parser.Triple) (nodeID string, ok bool) {
+	if node.NodeType != parser.BLANK {
+		return "", false
+	}
+	rdfNodeIDURI := rdf.NodeID.String()
+	nodeIDTriples := FilterTriples(nodeToTriples[node.String()], nil, &rdfNodeIDURI, nil)
+	if len(nodeIDTriples) != 1 {
+		return "", false
+	}
+	return nodeIDTriples[0].Object.ID, true
+}
+
+// returns the blank nodes which are referred by their rdf:nodeID and are not
+// one of the rootNodes. Only the nodes having triples other than the rdf:nodeID
+// triple are returned.
+func getNodeIDRoots(triples []*parser.Triple, rootNodes []*parser.Node, nodeToTriples map[string][]*parser.Triple) (nodes []*parser.Node) {
+	rdfNodeIDURI := rdf.NodeID.String()
+	isRoot := map[string]bool{}
+	for _, node := range rootNodes {
+		isRoot[node.String()] = true
+	}
+	for _, triple := range FilterTriples(triples, nil, &rdfNodeIDURI, nil) {
+		node := triple.Subject
+		if node.NodeType != parser.BLANK || isRoot[node.String()] || len(nodeToTriples[node.String()]) < 2 {
+			continue
+		}
+		isRoot[node.String()] = true
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// returns true if the node is a blank node without any triple of rdf:type predicate.
+// such nodes are generated by the rdf:parseType="Resource" property tags.
+func isUntypedBlankNode(node *parser.Node, nodeToTriples map[string][]*parser.Triple) bool {
+	if node.NodeType != parser.BLANK {
+		return false
+	}
+	rdfTypeURI := rdf.Type.String()
+	return len(FilterTriples(nodeToTriples[node.String()], nil, &rdfTypeURI, nil)) == 0
+}
+
+// returns the items of the rdf:List whose head is the given node.
+// ok is false if the node is not the head of a well-formed list.
+// A well-formed list is a chain of blank nodes ending in rdf:nil where every
+// blank node has exactly one rdf:first and one rdf:rest triple and nothing else.
+// Items of a well-formed list can't be literals because they are written as
+// node tags of the rdf:parseType="Collection" property tag.
+func getCollectionItems(node *parser.Node, nodeToTriples map[string][]*parser.Triple) (items []*parser.Node, ok bool) {
+	rdfFirstURI := rdf.First.String()
+	rdfRestURI := rdf.Rest.String()
+	rdfNilURI := rdf.Nil.String()
+
+	visited := map[string]bool{}
+	for !(node.NodeType == parser.IRI && node.ID == rdfNilURI) {
+		if node.NodeType != parser.BLANK || visited[node.String()] {
+			// either the list doesn't end with rdf:nil or has a cycle.
+			return nil, false
+		}
+		visited[node.String()] = true
+
+		triples := nodeToTriples[node.String()]
+		firstTriples := FilterTriples(triples, nil, &rdfFirstURI, nil)
+		restTriples := FilterTriples(triples, nil, &rdfRestURI, nil)
+		if len(triples) != 2 || len(firstTriples) != 1 || len(restTriples) != 1 {
+			return nil, false
+		}
+		if firstTriples[0].Object.NodeType == parser.LITERAL {
+			return nil, false
+		}
+		items = append(items, firstTriples[0].Object)
+		node = restTriples[0].Object
+	}
+	return items, true
+}
+
+// returns the triples with the container membership triples (rdf:_1,
+// rdf:_2, ...) moved after the other triples in the increasing order of
+// their index. Order of the other triples is retained.
+// isLi is true if the members are numbered 1, 2, ... without any gap.
+// Such members can be written as rdf:li tags.
+func sortContainerMembers(triples []*parser.Triple) (sortedTriples []*parser.Triple, isLi bool) {
+	var memberTriples []*parser.Triple
+	for _, triple := range triples {
+		if _, ok := rdf.MemberIndex(triple.Predicate.ID); ok {
+			memberTriples = append(memberTriples, triple)
+		} else {
+			sortedTriples = append(sortedTriples, triple)
+		}
+	}
+	sort.SliceStable(memberTriples, func(i, j int) bool {
+		ni, _ := rdf.MemberIndex(memberTriples[i].Predicate.ID)
+		nj, _ := rdf.MemberIndex(memberTriples[j].Predicate.ID)
+		return ni < nj
+	})
+	isLi = true
+	for i, triple := range memberTriples {
+		if n, _ := rdf.MemberIndex(triple.Predicate.ID); n != i+1 {
+			isLi = false
+		}
+	}
+	return append(sortedTriples, memberTriples...), isLi
+}
+
+// CollapseReifications returns the triples without the ones that reify
+// another triple of the graph and a map from the hash of every reified triple
+// to the statement node reifying it. Such a reification is written as the
+// rdf:ID attribute of the property tag of the reified triple.
+// A statement node is collapsed only if it is an IRI with a fragment and has
+// exactly one rdf:subject, rdf:predicate and rdf:object triple. rdf:type
+// triple of the statement node is retained if the node has other triples
+// like annotations.
+func CollapseReifications(triples []*parser.Triple) (restTriples []*parser.Triple, reifiedBy map[string]*parser.Node) {
+	rdfTypeURI := rdf.Type.String()
+	rdfSubjectURI := rdf.Subject.String()
+	rdfPredicateURI := rdf.Predicate.String()
+	rdfObjectURI := rdf.Object.String()
+
+	nodeToTriples := GetNodeToTriples(triples)
+	tripleSet := map[string]bool{}
+	for _, triple := range triples {
+		tripleSet[triple.Hash()] = true
+	}
+
+	// hashes of the triples to be removed.
+	removed := map[string]bool{}
+	reifiedBy = map[string]*parser.Node{}
+	for _, triple := range triples {
+		if triple.Predicate.ID != rdfTypeURI || triple.Object.ID != rdf.Statement.String() {
+			continue
+		}
+		statement := triple.Subject
+		if statement.NodeType != parser.IRI || getFragment(statement.ID) == "" {
+			continue
+		}
+		statementTriples := nodeToTriples[statement.String()]
+		subjects := FilterTriples(statementTriples, nil, &rdfSubjectURI, nil)
+		predicates := FilterTriples(statementTriples, nil, &rdfPredicateURI, nil)
+		objects := FilterTriples(statementTriples, nil, &rdfObjectURI, nil)
+		if len(subjects) != 1 || len(predicates) != 1 || len(objects) != 1 {
+			continue
+		}
+		reified := &parser.Triple{
+			Subject:   subjects[0].Object,
+			Predicate: predicates[0].Object,
+			Object:    objects[0].Object,
+		}
+		if !tripleSet[reified.Hash()] || reifiedBy[reified.Hash()] != nil {
+			// either the reified triple is not in the graph or
+			// it is already reified by another statement node.
+			continue
+		}
+		reifiedBy[reified.Hash()] = statement
+		removed[subjects[0].Hash()] = true
+		removed[predicates[0].Hash()] = true
+		removed[objects[0].Hash()] = true
+		if len(statementTriples) == 4 {
+			removed[triple.Hash()] = true
+		}
+	}
+
+	for _, triple := range triples {
+		if !removed[triple.Hash()] {
+			restTriples = append(restTriples, triple)
+		}
+	}
+	return restTriples, reifiedBy
+}
+
+// returns the base uri shared by most of the statement nodes. The base uri
+// of a statement node is its IRI without the fragment. Ties are broken by
+// the smallest base uri. Returns an empty string if there isn't any
+// statement node.
+func getDocumentBase(reifiedBy map[string]*parser.Node) (base string) {
+	count := map[string]int{}
+	for _, statement := range reifiedBy {
+		count[strings.TrimSuffix(statement.ID, "#"+getFragment(statement.ID))]++
+	}
+	for statementBase, n := range count {
+		if n > count[base] || n == count[base] && statementBase < base {
+			base = statementBase
+		}
+	}
+	return base
+}
+
+// returns the fragment of the uri. the fragment is the part after the last #.
+func getFragment(uri string) string {
+	idx := strings.LastIndex(uri, "#")
+	if idx == -1 {
+		return ""
+	}
+	return uri[idx+1:]
+}
+
+// replaces the characters which can't be written as they are in the text
+// content of a xml tag with the references to the characters.
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#13;")
+
+// same as textEscaper but for an attribute value enclosed in double quotes.
+// whitespaces other than a space are escaped because a xml reader replaces
+// them with a space otherwise.
+var attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#9;", "\n", "&#10;", "\r", "&#13;")
+
+// returns the text escaped to be written as the content of a xml tag.
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// returns the value escaped to be written as an attribute value.
+func escapeAttribute(value string) string {
+	return attributeEscaper.Replace(value)
+}
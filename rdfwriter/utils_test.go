@@ -1,6 +1,7 @@
 package rdfwriter
 
 import (
+	"github.com/spdx/gordf/namespace"
 	"github.com/spdx/gordf/rdfloader/parser"
 	"github.com/spdx/gordf/uri"
 	"reflect"
@@ -14,14 +15,6 @@ func max(n1, n2 int) int {
 	return n2
 }
 
-func getInvSchema() map[string]string {
-	return map[string]string{
-		"http://spdx.org/rdf/terms":                  "spdx",
-		"http://www.w3.org/1999/02/22-rdf-syntax-ns": "rdf",
-		"http://www.w3.org/2000/01/rdf-schema":       "rdfs",
-	}
-}
-
 // returns a slice of n blank nodes.
 // n > 0
 func getNBlankNodes(n int) (blankNodes []*parser.Node) {
@@ -93,7 +86,7 @@ func TestTopologicalSortTriples(t *testing.T) {
 	//        (N1)
 	// (N0) --------> (N2)
 	triples := []*parser.Triple{
-		{nodes[0], nodes[1], nodes[2]},
+		{Subject: nodes[0], Predicate: nodes[1], Object: nodes[2]},
 	}
 	// but it doesn't exist in the keys of the map.
 	sortedTriples, err := TopologicalSortTriples(triples)
@@ -338,34 +331,20 @@ func Test_any(t *testing.T) {
 	}
 }
 
-func Test_invertSchemaDefinition(t *testing.T) {
-	// no special variations to test for.
-	spdxString := "http://spdx.org/rdf/terms"
-	spdxURI, _ := uri.NewURIRef(parser.RDFNS)
-	schemaDefinition := map[string]uri.URIRef{
-		"spdx": spdxURI,
-	}
-
-	inv := invertSchemaDefinition(schemaDefinition)
-	expected := map[string]string{spdxString: "spdx"}
-	if reflect.DeepEqual(inv, expected) {
-		t.Errorf("expected %v, found %v", expected, inv)
-	}
-}
-
 func Test_getRDFNSAbbreviation(t *testing.T) {
-	invSchemaDefinition := make(map[string]string)
+	namespaces := namespace.NewManager()
 
 	// TestCase 1: Default prefix of the rdf namespace should be "rdf"
-	abbrev := getRDFNSAbbreviation(invSchemaDefinition)
+	abbrev := getRDFNSAbbreviation(namespaces)
 	if abbrev != "rdf" {
 		t.Errorf("by default, the rdf namespace should be abbreviated by rdf and not %s", abbrev)
 	}
 
-	// TestCase 2: Case when the rdf namespace exists in the invSchemaDefinition.
+	// TestCase 2: Case when the rdf namespace is bound to a prefix.
 	expectedAbbrev := "rdfNS"
-	invSchemaDefinition[parser.RDFNS] = expectedAbbrev
-	abbrev = getRDFNSAbbreviation(invSchemaDefinition)
+	rdfNS, _ := uri.NewURIRef(parser.RDFNS)
+	namespaces.Bind(expectedAbbrev, rdfNS)
+	abbrev = getRDFNSAbbreviation(namespaces)
 	if abbrev != expectedAbbrev {
 		t.Errorf("expected %s abbreviation, found %v", expectedAbbrev, abbrev)
 	}
@@ -405,37 +384,6 @@ func Test_getRestTriples(t *testing.T) {
 	}
 }
 
-func Test_shortenURI(t *testing.T) {
-	invSchema := getInvSchema()
-
-	// TestCase 1: uri doesn't have two fragments
-	//             Must raise an error
-	uriref := "http://spdx.org/rdf/terms#"
-	_, err := shortenURI(uriref, invSchema)
-	if err == nil {
-		t.Errorf("didn't raise any error for url with no fragment")
-	}
-
-	// TestCase 2: uri with inexistent baseURI
-	//             Must raise an error
-	uriref = "https://www.googlge.com/terms#website"
-	_, err = shortenURI(uriref, invSchema)
-	if err == nil {
-		t.Errorf("expected an error stating baseURI doesn't exist in the given schema")
-	}
-
-	// TestCase 3: valid uri
-	uriref = "http://spdx.org/rdf/terms#Snippet"
-	expectedOP := "spdx:Snippet"
-	shortURI, err := shortenURI(uriref, invSchema)
-	if err != nil {
-		t.Errorf("unexpected error converting a valid URI")
-	}
-	if shortURI != expectedOP {
-		t.Errorf("expected output: %v, found: %v", expectedOP, shortURI)
-	}
-}
-
 func Test_getRootNodes(t *testing.T) {
 	nodes := getNBlankNodes(10)
 
@@ -471,3 +419,200 @@ func Test_getRootNodes(t *testing.T) {
 		t.Errorf("expected %v root nodes, found %v nodes", len(roots), len(triples))
 	}
 }
+
+func Test_isUntypedBlankNode(t *testing.T) {
+	nodes := getNBlankNodes(3)
+	rdfType := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"}
+	spdxName := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#name"}
+
+	// TestCase 1: blank node without any triple is untyped.
+	if !isUntypedBlankNode(nodes[0], GetNodeToTriples(nil)) {
+		t.Errorf("blank node without any triples must be untyped")
+	}
+
+	// TestCase 2: blank node with rdf:type triple is typed.
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: rdfType, Object: &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#File"}},
+		{Subject: nodes[1], Predicate: spdxName, Object: &parser.Node{NodeType: parser.LITERAL, ID: "name"}},
+	}
+	nodeToTriples := GetNodeToTriples(triples)
+	if isUntypedBlankNode(nodes[0], nodeToTriples) {
+		t.Errorf("blank node with a rdf:type triple must not be untyped")
+	}
+
+	// TestCase 3: blank node with triples other than rdf:type is untyped.
+	if !isUntypedBlankNode(nodes[1], nodeToTriples) {
+		t.Errorf("blank node without a rdf:type triple must be untyped")
+	}
+
+	// TestCase 4: IRI nodes are never untyped blank nodes.
+	if isUntypedBlankNode(spdxName, nodeToTriples) {
+		t.Errorf("an IRI node must not be reported as an untyped blank node")
+	}
+}
+
+func Test_getCollectionItems(t *testing.T) {
+	nodes := getNBlankNodes(3)
+	rdfFirst := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "first"}
+	rdfRest := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "rest"}
+	rdfNil := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "nil"}
+	itemA := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#File"}
+	itemB := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#Package"}
+
+	// TestCase 1: rdf:nil is an empty list.
+	items, ok := getCollectionItems(rdfNil, GetNodeToTriples(nil))
+	if !ok || len(items) != 0 {
+		t.Errorf("rdf:nil must be an empty list. found %v, %v", items, ok)
+	}
+
+	// TestCase 2: well-formed list with two items.
+	triples := []*parser.Triple{
+		{Subject: nodes[0], Predicate: rdfFirst, Object: itemA},
+		{Subject: nodes[0], Predicate: rdfRest, Object: nodes[1]},
+		{Subject: nodes[1], Predicate: rdfFirst, Object: itemB},
+		{Subject: nodes[1], Predicate: rdfRest, Object: rdfNil},
+	}
+	items, ok = getCollectionItems(nodes[0], GetNodeToTriples(triples))
+	if !ok {
+		t.Errorf("expected a well-formed list")
+	}
+	if !reflect.DeepEqual(items, []*parser.Node{itemA, itemB}) {
+		t.Errorf("expected items %v, found %v", []*parser.Node{itemA, itemB}, items)
+	}
+
+	// TestCase 3: list not ending in rdf:nil is not well-formed.
+	triples[3].Object = nodes[2]
+	_, ok = getCollectionItems(nodes[0], GetNodeToTriples(triples))
+	if ok {
+		t.Errorf("list not ending in rdf:nil must not be well-formed")
+	}
+
+	// TestCase 4: list with a cycle is not well-formed.
+	triples[3].Object = nodes[0]
+	_, ok = getCollectionItems(nodes[0], GetNodeToTriples(triples))
+	if ok {
+		t.Errorf("list with a cycle must not be well-formed")
+	}
+
+	// TestCase 5: list node with extra triples is not well-formed.
+	triples[3].Object = rdfNil
+	triples = append(triples, &parser.Triple{
+		Subject:   nodes[1],
+		Predicate: &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#name"},
+		Object:    &parser.Node{NodeType: parser.LITERAL, ID: "name"},
+	})
+	_, ok = getCollectionItems(nodes[0], GetNodeToTriples(triples))
+	if ok {
+		t.Errorf("list node with extra triples must not be well-formed")
+	}
+
+	// TestCase 6: list with a literal item is not well-formed.
+	triples = triples[:4]
+	triples[0].Object = &parser.Node{NodeType: parser.LITERAL, ID: "literal"}
+	_, ok = getCollectionItems(nodes[0], GetNodeToTriples(triples))
+	if ok {
+		t.Errorf("list with a literal item can't be written as a collection")
+	}
+}
+
+func Test_sortContainerMembers(t *testing.T) {
+	nodes := getNBlankNodes(1)
+	triple := func(predicate string) *parser.Triple {
+		return &parser.Triple{
+			Subject:   nodes[0],
+			Predicate: &parser.Node{NodeType: parser.IRI, ID: predicate},
+			Object:    &parser.Node{NodeType: parser.LITERAL, ID: predicate},
+		}
+	}
+	name := triple("http://spdx.org/rdf/terms#name")
+	first, second, third := triple(parser.RDFNS+"_1"), triple(parser.RDFNS+"_2"), triple(parser.RDFNS+"_3")
+
+	// TestCase 1: members are moved after the other triples in order.
+	sortedTriples, isLi := sortContainerMembers([]*parser.Triple{second, name, first})
+	if !reflect.DeepEqual(sortedTriples, []*parser.Triple{name, first, second}) {
+		t.Errorf("expected %v, found %v", []*parser.Triple{name, first, second}, sortedTriples)
+	}
+	if !isLi {
+		t.Errorf("members without a gap can be written as rdf:li")
+	}
+
+	// TestCase 2: members with a gap can't be written as rdf:li
+	_, isLi = sortContainerMembers([]*parser.Triple{third, first})
+	if isLi {
+		t.Errorf("members with a gap must not be written as rdf:li")
+	}
+}
+
+func Test_getDocumentBase(t *testing.T) {
+	// TestCase 1: no statement node
+	if base := getDocumentBase(nil); base != "" {
+		t.Errorf("expected an empty base, found %v", base)
+	}
+
+	// TestCase 2: base of most of the statement nodes
+	reifiedBy := map[string]*parser.Node{
+		"a": {NodeType: parser.IRI, ID: "http://example.com/b#1"},
+		"b": {NodeType: parser.IRI, ID: "http://example.com/a#2"},
+		"c": {NodeType: parser.IRI, ID: "http://example.com/b#3"},
+	}
+	if base := getDocumentBase(reifiedBy); base != "http://example.com/b" {
+		t.Errorf("expected http://example.com/b, found %v", base)
+	}
+
+	// TestCase 3: ties are broken by the smallest base
+	delete(reifiedBy, "c")
+	if base := getDocumentBase(reifiedBy); base != "http://example.com/a" {
+		t.Errorf("expected http://example.com/a, found %v", base)
+	}
+}
+
+func Test_CollapseReifications(t *testing.T) {
+	rdfType := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "type"}
+	statementType := &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "Statement"}
+	document := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT"}
+	name := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/rdf/terms#name"}
+	value := &parser.Node{NodeType: parser.LITERAL, ID: "doc"}
+	statement := &parser.Node{NodeType: parser.IRI, ID: "http://spdx.org/spdxdocs/doc#name-1"}
+	reifiedTriple := &parser.Triple{Subject: document, Predicate: name, Object: value}
+	reification := []*parser.Triple{
+		{Subject: statement, Predicate: rdfType, Object: statementType},
+		{Subject: statement, Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "subject"}, Object: document},
+		{Subject: statement, Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "predicate"}, Object: name},
+		{Subject: statement, Predicate: &parser.Node{NodeType: parser.IRI, ID: parser.RDFNS + "object"}, Object: value},
+	}
+
+	// TestCase 1: reification quad of a triple of the graph is collapsed.
+	triples := append([]*parser.Triple{reifiedTriple}, reification...)
+	restTriples, reifiedBy := CollapseReifications(triples)
+	if !reflect.DeepEqual(restTriples, []*parser.Triple{reifiedTriple}) {
+		t.Errorf("expected only the reified triple, found %v", restTriples)
+	}
+	if reifiedBy[reifiedTriple.Hash()] != statement {
+		t.Errorf("expected %v to be reified by %v, found %v", reifiedTriple, statement, reifiedBy)
+	}
+
+	// TestCase 2: rdf:type triple of an annotated statement is retained.
+	comment := &parser.Triple{
+		Subject:   statement,
+		Predicate: &parser.Node{NodeType: parser.IRI, ID: "http://www.w3.org/2000/01/rdf-schema#comment"},
+		Object:    &parser.Node{NodeType: parser.LITERAL, ID: "annotation"},
+	}
+	triples = append(triples, comment)
+	restTriples, _ = CollapseReifications(triples)
+	if !reflect.DeepEqual(restTriples, []*parser.Triple{reifiedTriple, reification[0], comment}) {
+		t.Errorf("expected the reified triple, rdf:type and the annotation. found %v", restTriples)
+	}
+
+	// TestCase 3: reification of a triple not in the graph is not collapsed.
+	restTriples, reifiedBy = CollapseReifications(reification)
+	if len(restTriples) != len(reification) || len(reifiedBy) != 0 {
+		t.Errorf("reification of a missing triple must not be collapsed. found %v", restTriples)
+	}
+
+	// TestCase 4: statement node without a fragment is not collapsed.
+	statement.ID = "http://spdx.org/spdxdocs/statement"
+	restTriples, _ = CollapseReifications(append([]*parser.Triple{reifiedTriple}, reification...))
+	if len(restTriples) != len(reification)+1 {
+		t.Errorf("statement without a fragment can't be written as rdf:ID. found %v", restTriples)
+	}
+}
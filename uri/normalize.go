@@ -0,0 +1,133 @@
+// Syntax-based normalization of IRIs according to RFC 3987.
+
+package uri
+
+import (
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode/utf8"
+)
+
+// Normalize returns the normalized form of the iri. Two iris referring to the
+// same resource are normalized to the same string by:
+//  1. converting the characters to Unicode Normalization Form C,
+//  2. lowercasing the scheme and the host,
+//  3. uppercasing the hexadecimal digits of the percent-encoded octets,
+//  4. decoding the percent-encoded octets of the unreserved characters and
+//  5. removing the "." and ".." segments from the path.
+//
+// For example:
+//
+//	Normalize("HTTP://SPDX.org/a/./b/../%7euser/%c3%a9") -> http://spdx.org/a/~user/é
+func Normalize(iri string) string {
+	c := parseComponents(iri)
+	c.scheme = strings.ToLower(c.scheme)
+	c.authority = normalizePercentEncoding(c.authority)
+	if idx := strings.LastIndex(c.authority, "@"); idx != -1 {
+		// userinfo is case-sensitive.
+		c.authority = c.authority[:idx+1] + lowerASCII(c.authority[idx+1:])
+	} else {
+		c.authority = lowerASCII(c.authority)
+	}
+	c.path = normalizePercentEncoding(c.path)
+	if c.hasScheme && (c.hasAuthority || strings.HasPrefix(c.path, "/")) {
+		c.path = removeDotSegments(c.path)
+	}
+	c.query = normalizePercentEncoding(c.query)
+	c.fragment = normalizePercentEncoding(c.fragment)
+	return norm.NFC.String(c.String())
+}
+
+// Normalize returns the uriref normalized like the Normalize function.
+func (uriref *URIRef) Normalize() URIRef {
+	return URIRef{parseComponents(Normalize(uriref.String()))}
+}
+
+// lowercases the ASCII letters of the text except the ones in the
+// percent-encoded octets.
+func lowerASCII(text string) string {
+	var result strings.Builder
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case ch == '%' && i+2 < len(text):
+			result.WriteString(text[i : i+3])
+			i += 2
+		case 'A' <= ch && ch <= 'Z':
+			result.WriteByte(ch + 'a' - 'A')
+		default:
+			result.WriteByte(ch)
+		}
+	}
+	return result.String()
+}
+
+// returns true if the character is an unreserved character of an iri.
+// iunreserved = ALPHA / DIGIT / "-" / "." / "_" / "~" / ucschar
+func isIUnreserved(r rune) bool {
+	switch {
+	case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		return true
+	case r == '-', r == '.', r == '_', r == '~':
+		return true
+	case 0xA0 <= r && r <= 0xD7FF, 0xF900 <= r && r <= 0xFDCF, 0xFDF0 <= r && r <= 0xFFEF:
+		return true
+	case 0x10000 <= r && r <= 0xEFFFD:
+		// last two code points of every plane and the first 0x1000 code
+		// points of the plane 14 are excluded.
+		return r&0xFFFF <= 0xFFFD && (r < 0xE0000 || r >= 0xE1000)
+	}
+	return false
+}
+
+// returns the value of a hexadecimal digit or -1 if it isn't one.
+func hexValue(ch byte) int {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return int(ch - '0')
+	case 'a' <= ch && ch <= 'f':
+		return int(ch-'a') + 10
+	case 'A' <= ch && ch <= 'F':
+		return int(ch-'A') + 10
+	}
+	return -1
+}
+
+// decodes the percent-encoded UTF-8 characters which are unreserved and
+// uppercases the hexadecimal digits of the other percent-encoded octets.
+// Malformed percent-encodings are kept as they are.
+func normalizePercentEncoding(component string) string {
+	if !strings.Contains(component, "%") {
+		return component
+	}
+	var result strings.Builder
+	for i := 0; i < len(component); {
+		// decoding a run of percent-encoded octets.
+		var octets []byte
+		for i+2 < len(component) && component[i] == '%' {
+			high, low := hexValue(component[i+1]), hexValue(component[i+2])
+			if high == -1 || low == -1 {
+				break
+			}
+			octets = append(octets, byte(high<<4|low))
+			i += 3
+		}
+		for len(octets) > 0 {
+			r, size := utf8.DecodeRune(octets)
+			if r != utf8.RuneError && isIUnreserved(r) {
+				result.Write(octets[:size])
+			} else {
+				for _, octet := range octets[:size] {
+					result.WriteString(fmt.Sprintf("%%%02X", octet))
+				}
+			}
+			octets = octets[size:]
+		}
+		if i < len(component) {
+			result.WriteByte(component[i])
+			i++
+		}
+	}
+	return result.String()
+}
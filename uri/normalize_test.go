@@ -0,0 +1,42 @@
+package uri
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	iris := map[string]string{
+		// TestCase 1: scheme and host are case-insensitive.
+		"HTTP://SPDX.org/licenses/MIT":      "http://spdx.org/licenses/MIT",
+		"http://User@Example.COM:80/A":      "http://User@example.com:80/A",
+		"http://spdx.org/licenses/MIT#Frag": "http://spdx.org/licenses/MIT#Frag",
+		// TestCase 2: percent-encoded unreserved characters are decoded and
+		// the other percent-encoded octets are uppercased.
+		"http://spdx.org/%7euser/%41%2fb": "http://spdx.org/~user/A%2Fb",
+		"http://spdx.org/%c3%a9?q=%c3%a9": "http://spdx.org/é?q=é",
+		"http://spdx.org/%ef%bf%bf%20a":   "http://spdx.org/%EF%BF%BF%20a",
+		"http://spdx.org/%ff%2":           "http://spdx.org/%FF%2",
+		"http://SPDX.%6Frg/%zz":           "http://spdx.org/%zz",
+		// TestCase 3: dot segments are removed from the path.
+		"http://spdx.org/a/./b/../c": "http://spdx.org/a/c",
+		"http://spdx.org/a/%2E%2E/c": "http://spdx.org/c",
+		"urn:example:./a":            "urn:example:./a",
+		// TestCase 4: characters are converted to the normalization form C.
+		"http://spdx.org/cafe\u0301":   "http://spdx.org/caf\u00e9",
+		"http://spdx.org/caf%65%CC%81": "http://spdx.org/caf\u00e9",
+	}
+	for iri, expected := range iris {
+		if output := Normalize(iri); output != expected {
+			t.Errorf("normalizing %v: expected %v, found %v", iri, expected, output)
+		}
+	}
+}
+
+func TestURIRef_Normalize(t *testing.T) {
+	uriref, _ := NewURIRef("HTTP://spdx.org/licenses/./MIT")
+	expected, _ := NewURIRef("http://spdx.org/licenses/MIT")
+	normalized := uriref.Normalize()
+	if !normalized.Equal(expected) {
+		t.Errorf("expected %v, found %v", expected.String(), normalized.String())
+	}
+}
@@ -0,0 +1,150 @@
+// Resolution of relative references according to RFC 3986.
+
+package uri
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// regular expression given in the Appendix B of RFC 3986 which splits a uri
+// reference into its five components.
+var uriComponentsRegex = regexp.MustCompile(`^(([^:/?#]+):)?(//([^/?#]*))?([^?#]*)(\?([^#]*))?(#(.*))?$`)
+
+// components of a uri reference.
+// A component can be defined but empty. For example, "http://a/b?" has an
+// empty query component while "http://a/b" doesn't have a query at all.
+type components struct {
+	scheme, authority, path, query, fragment       string
+	hasScheme, hasAuthority, hasQuery, hasFragment bool
+}
+
+// splits a uri reference into its components.
+func parseComponents(reference string) (c components) {
+	match := uriComponentsRegex.FindStringSubmatch(reference)
+	if match == nil {
+		// the regex matches every string. This is unreachable.
+		return components{path: reference}
+	}
+	c.scheme, c.hasScheme = match[2], match[1] != ""
+	c.authority, c.hasAuthority = match[4], match[3] != ""
+	c.path = match[5]
+	c.query, c.hasQuery = match[7], match[6] != ""
+	c.fragment, c.hasFragment = match[9], match[8] != ""
+	return c
+}
+
+// recomposes the components into a uri reference. Section 5.3 of RFC 3986.
+func (c components) String() string {
+	var result strings.Builder
+	if c.hasScheme {
+		result.WriteString(c.scheme + ":")
+	}
+	if c.hasAuthority {
+		result.WriteString("//" + c.authority)
+	}
+	result.WriteString(c.path)
+	if c.hasQuery {
+		result.WriteString("?" + c.query)
+	}
+	if c.hasFragment {
+		result.WriteString("#" + c.fragment)
+	}
+	return result.String()
+}
+
+// removes the "." and ".." segments from the path. Section 5.2.4 of RFC 3986.
+func removeDotSegments(path string) string {
+	var output []string
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, "../"):
+			path = path[3:]
+		case strings.HasPrefix(path, "./"):
+			path = path[2:]
+		case strings.HasPrefix(path, "/./"):
+			path = path[2:]
+		case path == "/.":
+			path = "/"
+		case strings.HasPrefix(path, "/../"):
+			path = path[3:]
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case path == "/..":
+			path = "/"
+			if len(output) > 0 {
+				output = output[:len(output)-1]
+			}
+		case path == "." || path == "..":
+			path = ""
+		default:
+			// moving the first segment of the path to the output.
+			// the segment includes the initial "/" if any.
+			end := strings.Index(path[1:], "/")
+			if end == -1 {
+				end = len(path)
+			} else {
+				end++
+			}
+			output = append(output, path[:end])
+			path = path[end:]
+		}
+	}
+	return strings.Join(output, "")
+}
+
+// merges a relative path with the path of the base. Section 5.2.3 of RFC 3986.
+func mergePaths(base components, path string) string {
+	if base.hasAuthority && base.path == "" {
+		return "/" + path
+	}
+	lastSlashIdx := strings.LastIndex(base.path, "/")
+	return base.path[:lastSlashIdx+1] + path
+}
+
+// Resolve returns the target uri of the reference relative to the base uri
+// using the algorithm of section 5.2.2 of RFC 3986. Base must be an absolute
+// uri. Reference can be absolute or relative.
+// For example:
+//
+//	Resolve("http://spdx.org/rdf/terms/doc.rdf", "#SPDXRef-1")
+//	    -> http://spdx.org/rdf/terms/doc.rdf#SPDXRef-1
+//	Resolve("http://spdx.org/rdf/terms/doc.rdf", "../licenses/MIT")
+//	    -> http://spdx.org/rdf/licenses/MIT
+func Resolve(base, reference string) (string, error) {
+	baseComponents := parseComponents(base)
+	if !baseComponents.hasScheme {
+		return "", fmt.Errorf("base uri %v must be an absolute uri", base)
+	}
+	return resolveComponents(baseComponents, parseComponents(reference)).String(), nil
+}
+
+// resolves the components of a reference against the components of a base.
+func resolveComponents(base, reference components) (target components) {
+	switch {
+	case reference.hasScheme:
+		target = reference
+		target.path = removeDotSegments(reference.path)
+	case reference.hasAuthority:
+		target = reference
+		target.scheme, target.hasScheme = base.scheme, base.hasScheme
+		target.path = removeDotSegments(reference.path)
+	case reference.path == "":
+		target = base
+		if reference.hasQuery {
+			target.query, target.hasQuery = reference.query, true
+		}
+	default:
+		target = base
+		if strings.HasPrefix(reference.path, "/") {
+			target.path = removeDotSegments(reference.path)
+		} else {
+			target.path = removeDotSegments(mergePaths(base, reference.path))
+		}
+		target.query, target.hasQuery = reference.query, reference.hasQuery
+	}
+	target.fragment, target.hasFragment = reference.fragment, reference.hasFragment
+	return target
+}
@@ -0,0 +1,98 @@
+package uri
+
+import (
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	// TestCase 1: base uri must be absolute
+	_, err := Resolve("/relative/base", "#frag")
+	if err == nil {
+		t.Errorf("expected an error for a relative base uri")
+	}
+
+	// TestCase 2: examples given in the section 5.4 of RFC 3986.
+	base := "http://a/b/c/d;p?q"
+	examples := map[string]string{
+		// normal examples
+		"g:h":     "g:h",
+		"g":       "http://a/b/c/g",
+		"./g":     "http://a/b/c/g",
+		"g/":      "http://a/b/c/g/",
+		"/g":      "http://a/g",
+		"//g":     "http://g",
+		"?y":      "http://a/b/c/d;p?y",
+		"g?y":     "http://a/b/c/g?y",
+		"#s":      "http://a/b/c/d;p?q#s",
+		"g#s":     "http://a/b/c/g#s",
+		"g?y#s":   "http://a/b/c/g?y#s",
+		";x":      "http://a/b/c/;x",
+		"g;x":     "http://a/b/c/g;x",
+		"g;x?y#s": "http://a/b/c/g;x?y#s",
+		"":        "http://a/b/c/d;p?q",
+		".":       "http://a/b/c/",
+		"./":      "http://a/b/c/",
+		"..":      "http://a/b/",
+		"../":     "http://a/b/",
+		"../g":    "http://a/b/g",
+		"../..":   "http://a/",
+		"../../":  "http://a/",
+		"../../g": "http://a/g",
+		// abnormal examples
+		"../../../g":    "http://a/g",
+		"../../../../g": "http://a/g",
+		"/./g":          "http://a/g",
+		"/../g":         "http://a/g",
+		"g.":            "http://a/b/c/g.",
+		".g":            "http://a/b/c/.g",
+		"g..":           "http://a/b/c/g..",
+		"..g":           "http://a/b/c/..g",
+		"./../g":        "http://a/b/g",
+		"./g/.":         "http://a/b/c/g/",
+		"g/./h":         "http://a/b/c/g/h",
+		"g/../h":        "http://a/b/c/h",
+		"g;x=1/./y":     "http://a/b/c/g;x=1/y",
+		"g;x=1/../y":    "http://a/b/c/y",
+		"g?y/./x":       "http://a/b/c/g?y/./x",
+		"g?y/../x":      "http://a/b/c/g?y/../x",
+		"g#s/./x":       "http://a/b/c/g#s/./x",
+		"g#s/../x":      "http://a/b/c/g#s/../x",
+		"http:g":        "http:g",
+	}
+	for reference, expected := range examples {
+		output, err := Resolve(base, reference)
+		if err != nil {
+			t.Errorf("unexpected error resolving %v: %v", reference, err)
+			continue
+		}
+		if output != expected {
+			t.Errorf("resolving %v: expected %v, found %v", reference, expected, output)
+		}
+	}
+
+	// TestCase 3: fragment of the base is dropped.
+	output, _ := Resolve("http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT", "#SPDXRef-1")
+	if expected := "http://spdx.org/spdxdocs/doc#SPDXRef-1"; output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+	output, _ = Resolve("http://spdx.org/spdxdocs/doc#SPDXRef-DOCUMENT", "")
+	if expected := "http://spdx.org/spdxdocs/doc"; output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+
+	// TestCase 4: base with an authority and an empty path
+	output, _ = Resolve("http://spdx.org", "licenses/MIT")
+	if expected := "http://spdx.org/licenses/MIT"; output != expected {
+		t.Errorf("expected %v, found %v", expected, output)
+	}
+}
+
+func Test_removeDotSegments(t *testing.T) {
+	// examples given in the section 5.2.4 of RFC 3986.
+	if output := removeDotSegments("/a/b/c/./../../g"); output != "/a/g" {
+		t.Errorf("expected /a/g, found %v", output)
+	}
+	if output := removeDotSegments("mid/content=5/../6"); output != "mid/6" {
+		t.Errorf("expected mid/6, found %v", output)
+	}
+}
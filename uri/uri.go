@@ -10,61 +10,162 @@ import (
 
 type URIRef struct {
 	/**
-	 * A URI Reference is formed of one or two components:
-	 * 		Base: base URL / URI. Can optionally end in # char
-	 * 		Fragment: relative component of url     [optional]
-	 * A valid uri is:
-	 *     base#fragment or base#
+	 * A URI Reference is made of the five components of RFC 3986:
+	 * 		scheme://authority/path?query#fragment
 	 * For example:
 	 * 		https://www.w3.org/TR/skos-reference/#L1302 is a valid URIRef with
-	 * 		    Base = https://www.w3.org/TR/skos-reference/
+	 * 		    Scheme = https
+	 * 		    Authority = www.w3.org
+	 * 		    Path = /TR/skos-reference/
 	 * 		    Fragment = L1302
+	 * A URIRef is also used as a namespace to which the names are added.
+	 * Names are added as a fragment to a hash namespace and as the last
+	 * segment of the path to a slash namespace:
+	 * 		http://spdx.org/rdf/terms# + name  -> http://spdx.org/rdf/terms#name
+	 * 		http://purl.org/dc/terms/  + title -> http://purl.org/dc/terms/title
 	 */
-	uri string
+	components components
 }
 
 // constructor for URIRef
 func NewURIRef(uri string) (uriref URIRef, err error) {
 	/**
 	 * Usage and equivalence:
-	 * 		base := "https://www.w3.org/TR/skos-reference/"
-	 * 		fragment: "L1302"
-	 * 		uriref := NewURIRef(base, fragment)
-	 * 		uriref -> "https://www.w3.org/TR/skos-reference/#L1302"
+	 * 		uriref, err := NewURIRef("https://www.w3.org/TR/skos-reference/")
+	 * 		uriref -> "https://www.w3.org/TR/skos-reference/"
+	 * uri is kept as it is. A namespace uri which ends in neither # nor /
+	 * is a hash namespace.
 	 */
 
-	// validating the input uri
-	_, err = url.ParseRequestURI(uri)
+	// validating the input uri. It must be an absolute uri or an absolute path.
+	parsedURI, err := url.Parse(uri)
+	if err == nil && !parsedURI.IsAbs() && !strings.HasPrefix(uri, "/") {
+		err = fmt.Errorf("%q is not an absolute uri", uri)
+	}
 	if err != nil {
 		return uriref, fmt.Errorf("Malformed URI: %v", err)
 	}
+	return URIRef{parseComponents(uri)}, nil
+}
 
-	// adding a # to the end if it doesn't end in # already.
-	if !strings.HasSuffix(uri, "#") {
-		uri += "#"
+// AddFragment returns the uri of the name in the namespace of the uriref.
+// A name starting with a # char is always added as the fragment.
+// Returns an error if the name doesn't result in a valid uri.
+func (uriref *URIRef) AddFragment(frag string) (URIRef, error) {
+	c := uriref.components
+	switch {
+	case strings.HasPrefix(frag, "#"):
+		c.fragment, c.hasFragment = frag[1:], true
+	case c.hasFragment:
+		// hash namespace like http://spdx.org/rdf/terms#
+		c.fragment += frag
+	case !c.hasQuery && strings.HasSuffix(c.path, "/"):
+		// slash namespace like http://purl.org/dc/terms/
+		c.path += frag
+	default:
+		// namespace without a trailing # or / is a hash namespace.
+		c.fragment, c.hasFragment = frag, true
 	}
 
-	// validate uri after addition of # at the end
-	return URIRef{uri}, err
+	// validating the new uri
+	if _, err := url.Parse(c.String()); err != nil {
+		return URIRef{}, fmt.Errorf("invalid fragment %v for the uri %v: %v", frag, uriref, err)
+	}
+	return URIRef{c}, nil
 }
 
-// join the fragment to the uri of current object
-func (uriref *URIRef) AddFragment(frag string) (retURI URIRef) {
-	if strings.HasPrefix(frag, "#") {
-		frag = frag[1:]
+// Resolve returns the uri of the reference resolved against the uriref.
+// The uriref must be an absolute uri.
+func (uriref *URIRef) Resolve(reference string) (URIRef, error) {
+	if !uriref.components.hasScheme {
+		return URIRef{}, fmt.Errorf("base uri %v must be an absolute uri", uriref)
 	}
+	target := resolveComponents(uriref.components, parseComponents(reference))
+	if _, err := url.Parse(target.String()); err != nil {
+		return URIRef{}, fmt.Errorf("invalid reference %v: %v", reference, err)
+	}
+	return URIRef{target}, nil
+}
 
-	// validating the relative uri
-	_, err := url.ParseRequestURI(uriref.uri + frag)
-	if err != nil {
-		return
+// Relativize returns the shortest reference to the target relative to the
+// uriref. Resolving the returned reference against the uriref results in the
+// target. If the target doesn't share the scheme and the authority of the
+// uriref, the target is returned as it is.
+// For example:
+//
+//	http://spdx.org/rdf/terms/doc.rdf relativizes
+//	    http://spdx.org/rdf/terms/doc.rdf#SPDXRef-1 -> #SPDXRef-1
+//	    http://spdx.org/rdf/licenses/MIT            -> ../licenses/MIT
+func (uriref *URIRef) Relativize(target URIRef) string {
+	base, t := uriref.components, target.components
+	if !base.hasScheme || !strings.EqualFold(base.scheme, t.scheme) || base.hasAuthority != t.hasAuthority || base.authority != t.authority {
+		return target.String()
+	}
+	if base.path != t.path && !strings.HasPrefix(t.path, "/") {
+		// paths of the uris like urn:isbn:0451450523 aren't hierarchical.
+		return target.String()
+	}
+	relative := components{fragment: t.fragment, hasFragment: t.hasFragment}
+	switch {
+	case base.path == t.path && base.hasQuery == t.hasQuery && base.query == t.query:
+		// only the fragment differs.
+	case base.path == t.path && t.hasQuery:
+		relative.query, relative.hasQuery = t.query, true
+	default:
+		relative.path = relativePath(base.path, t.path)
+		relative.query, relative.hasQuery = t.query, t.hasQuery
+	}
+	return relative.String()
+}
+
+// returns the relative path which is merged with the base path to the
+// target path. targetPath must be an absolute path.
+func relativePath(basePath, targetPath string) string {
+	if !strings.HasPrefix(basePath, "/") {
+		return targetPath
+	}
+	// last segment of the base path is replaced by the relative path.
+	baseDirs := strings.Split(basePath[1:strings.LastIndex(basePath, "/")+1], "/")
+	baseDirs = baseDirs[:len(baseDirs)-1]
+	targetSegments := strings.Split(targetPath[1:], "/")
+	common := 0
+	for common < len(baseDirs) && common < len(targetSegments)-1 && baseDirs[common] == targetSegments[common] {
+		common++
 	}
+	relative := strings.Repeat("../", len(baseDirs)-common) + strings.Join(targetSegments[common:], "/")
+	switch {
+	case strings.HasPrefix(relative, "/"):
+		// an empty segment would be read as the start of an absolute path.
+		return targetPath
+	case relative == "", strings.Contains(strings.SplitN(relative, "/", 2)[0], ":"):
+		// a colon in the first segment would be read as a scheme.
+		return "./" + relative
+	}
+	return relative
+}
+
+// Equal returns true if both the uris are same. The scheme is compared
+// case-insensitively.
+func (uriref *URIRef) Equal(other URIRef) bool {
+	c, o := uriref.components, other.components
+	c.scheme, o.scheme = strings.ToLower(c.scheme), strings.ToLower(o.scheme)
+	return c == o
+}
+
+// Base returns the uri without its fragment.
+func (uriref *URIRef) Base() string {
+	c := uriref.components
+	c.fragment, c.hasFragment = "", false
+	return c.String()
+}
 
-	// relative uri is fine, return a new object of uriref.
-	return URIRef{uriref.uri + frag}
+// Fragment returns the fragment of the uri. It is empty if the uri doesn't
+// have a fragment.
+func (uriref *URIRef) Fragment() string {
+	return uriref.components.fragment
 }
 
 // returns string representation of the uriref
 func (uriref *URIRef) String() string {
-	return uriref.uri
+	return uriref.components.String()
 }
@@ -33,8 +33,18 @@ func TestNewURIRef(t *testing.T) {
 	if err != nil {
 		t.Errorf(err.Error())
 	}
-	if exp := uri + "#"; uriref.String() != exp {
-		t.Errorf("expected %v, found %v", exp, uriref)
+	if uriref.String() != uri {
+		t.Errorf("expected %v, found %v", uri, uriref)
+	}
+
+	// case when the uri is a slash namespace
+	uri = "http://purl.org/dc/terms/"
+	uriref, err = NewURIRef(uri)
+	if err != nil {
+		t.Errorf(err.Error())
+	}
+	if uriref.String() != uri {
+		t.Errorf("expected %v, found %v", uri, uriref)
 	}
 }
 
@@ -42,16 +52,19 @@ func TestURIRef_AddFragment(t *testing.T) {
 	uriString := "https://www.someuri.com/valid/uri"
 	uriref, _ := NewURIRef(uriString)
 
-	// TestCase 1: Invalid Fragment must return an empty uri
+	// TestCase 1: Invalid Fragment must raise an error
 	fragment := "%%%%"
-	newUri := uriref.AddFragment(fragment)
-	if newUri.String() != "" {
-		t.Errorf("invalid fragment must result in an empty uri. Found %v", newUri.String())
+	newUri, err := uriref.AddFragment(fragment)
+	if err == nil {
+		t.Errorf("invalid fragment must raise an error. Found %v", newUri.String())
 	}
 
 	// TestCase 2: valid fragment
 	fragment = "someFrag"
-	newUri = uriref.AddFragment(fragment)
+	newUri, err = uriref.AddFragment(fragment)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
 	expectedURI := uriString + "#" + fragment
 	if newUri.String() != expectedURI {
 		t.Errorf("expected: %v, found: %v", expectedURI, newUri)
@@ -59,17 +72,126 @@ func TestURIRef_AddFragment(t *testing.T) {
 
 	// TestCase 3: valid fragmnet starting with a hash char.
 	fragment = "#" + fragment
-	newUri = uriref.AddFragment(fragment)
+	newUri, _ = uriref.AddFragment(fragment)
 	if newUri.String() != expectedURI {
 		t.Errorf("expected %v, found %v", expectedURI, newUri)
 	}
+
+	// TestCase 4: names are added to the hash and the slash namespaces.
+	namespaces := map[string]string{
+		"http://spdx.org/rdf/terms#":  "http://spdx.org/rdf/terms#name",
+		"http://purl.org/dc/terms/":   "http://purl.org/dc/terms/name",
+		"http://example.com/a?b=c":    "http://example.com/a?b=c#name",
+		"http://example.com/a/?b=c/":  "http://example.com/a/?b=c/#name",
+		"http://example.com#prefix-":  "http://example.com#prefix-name",
+		"urn:example:animals:ferrets": "urn:example:animals:ferrets#name",
+	}
+	for namespace, expected := range namespaces {
+		uriref, _ := NewURIRef(namespace)
+		newUri, err := uriref.AddFragment("name")
+		if err != nil {
+			t.Errorf("unexpected error adding a name to %v: %v", namespace, err)
+		}
+		if newUri.String() != expected {
+			t.Errorf("expected %v, found %v", expected, newUri.String())
+		}
+	}
+}
+
+func TestURIRef_Resolve(t *testing.T) {
+	// TestCase 1: base uri must be absolute
+	uriref, _ := NewURIRef("/relative/base")
+	if _, err := uriref.Resolve("#frag"); err == nil {
+		t.Errorf("expected an error for a relative base uri")
+	}
+
+	// TestCase 2: relative reference
+	uriref, _ = NewURIRef("http://spdx.org/rdf/terms/doc.rdf#SPDXRef-DOCUMENT")
+	resolved, err := uriref.Resolve("../licenses/MIT")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if expected := "http://spdx.org/rdf/licenses/MIT"; resolved.String() != expected {
+		t.Errorf("expected %v, found %v", expected, resolved.String())
+	}
+
+	// TestCase 3: invalid reference must raise an error
+	if _, err = uriref.Resolve("#%%"); err == nil {
+		t.Errorf("expected an error for an invalid reference")
+	}
+}
+
+func TestURIRef_Relativize(t *testing.T) {
+	base := "http://a/b/c/d;p?q#f"
+	uriref, _ := NewURIRef(base)
+	targets := map[string]string{
+		"http://a/b/c/d;p?q#s": "#s",
+		"http://a/b/c/d;p?q":   "",
+		"http://a/b/c/d;p?y":   "?y",
+		"http://a/b/c/d;p":     "d;p",
+		"http://a/b/c/g":       "g",
+		"http://a/b/c/g/h?y#s": "g/h?y#s",
+		"http://a/b/c/":        "./",
+		"http://a/b/g":         "../g",
+		"http://a/g":           "../../g",
+		"http://a/":            "../../",
+		"http://a/b/c/g:h":     "./g:h",
+		"http://a//g":          "../..//g",
+		"https://a/b/c/g":      "https://a/b/c/g",
+		"http://x/b/c/g":       "http://x/b/c/g",
+		"urn:isbn:0451450523":  "urn:isbn:0451450523",
+	}
+	for target, expected := range targets {
+		targetURI, _ := NewURIRef(target)
+		relative := uriref.Relativize(targetURI)
+		if relative != expected {
+			t.Errorf("relativizing %v: expected %v, found %v", target, expected, relative)
+		}
+		// resolving the relative reference must result in the target.
+		if resolved, _ := Resolve(base, relative); resolved != target {
+			t.Errorf("resolving %v: expected %v, found %v", relative, target, resolved)
+		}
+	}
+}
+
+func TestURIRef_Equal(t *testing.T) {
+	uriref, _ := NewURIRef("http://spdx.org/rdf/terms#name")
+	same, _ := NewURIRef("HTTP://spdx.org/rdf/terms#name")
+	different, _ := NewURIRef("http://spdx.org/rdf/terms#Name")
+	if !uriref.Equal(same) {
+		t.Errorf("expected %v to be equal to %v", uriref.String(), same.String())
+	}
+	if uriref.Equal(different) {
+		t.Errorf("expected %v to be different from %v", uriref.String(), different.String())
+	}
+}
+
+func TestURIRef_Base_Fragment(t *testing.T) {
+	uriref, _ := NewURIRef("http://spdx.org/rdf/terms?a=b#name")
+	if expected := "http://spdx.org/rdf/terms?a=b"; uriref.Base() != expected {
+		t.Errorf("expected %v, found %v", expected, uriref.Base())
+	}
+	if uriref.Fragment() != "name" {
+		t.Errorf("expected name, found %v", uriref.Fragment())
+	}
+
+	// uri without a fragment
+	uriref, _ = NewURIRef("http://purl.org/dc/terms/title")
+	if uriref.Base() != uriref.String() || uriref.Fragment() != "" {
+		t.Errorf("expected %v without a fragment, found %v and %v", uriref.String(), uriref.Base(), uriref.Fragment())
+	}
 }
 
 func TestURIRef_String(t *testing.T) {
 	// again, nothing much to test
 	uriString := "https://www.someuri.com/valid/uri"
 	uriref, _ := NewURIRef(uriString)
-	if uriref.String() != uriString+"#" {
-		t.Errorf("expected: %v, found: %v", uriString+"#", uriref.String())
+	if uriref.String() != uriString {
+		t.Errorf("expected: %v, found: %v", uriString, uriref.String())
+	}
+
+	// zero value is an empty uri
+	if (&URIRef{}).String() != "" {
+		t.Errorf("expected an empty uri, found %v", (&URIRef{}).String())
 	}
 }